@@ -1,16 +1,62 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
 	"github.com/joho/godotenv"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/apperror"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/auth"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/llm"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/speech"
 	"github.com/takanoakira/ai-interview-practice/backend/internal/handler/company"
+	exportHandler "github.com/takanoakira/ai-interview-practice/backend/internal/handler/export"
+	importsHandler "github.com/takanoakira/ai-interview-practice/backend/internal/handler/imports"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/handler/interview_evaluation"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/handler/interview_session"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/handler/interview_voice"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/handler/job"
 	"github.com/takanoakira/ai-interview-practice/backend/internal/handler/job_posting"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/handler/organization"
+	speechHandler "github.com/takanoakira/ai-interview-practice/backend/internal/handler/speech"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/handler/trash"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/handler/user"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/llm/fake"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/llm/openai"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/middleware"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/prompt"
 	companyRepo "github.com/takanoakira/ai-interview-practice/backend/internal/repository/company"
+	exportRepo "github.com/takanoakira/ai-interview-practice/backend/internal/repository/export"
+	interviewEvaluationRepo "github.com/takanoakira/ai-interview-practice/backend/internal/repository/interview_evaluation"
+	interviewSessionRepo "github.com/takanoakira/ai-interview-practice/backend/internal/repository/interview_session"
+	jobRepo "github.com/takanoakira/ai-interview-practice/backend/internal/repository/job"
 	jobPostingRepo "github.com/takanoakira/ai-interview-practice/backend/internal/repository/job_posting"
+	organizationRepo "github.com/takanoakira/ai-interview-practice/backend/internal/repository/organization"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/repository/transaction"
+	userRepo "github.com/takanoakira/ai-interview-practice/backend/internal/repository/user"
+	fakeSpeech "github.com/takanoakira/ai-interview-practice/backend/internal/speech/fake"
+	openaiSpeech "github.com/takanoakira/ai-interview-practice/backend/internal/speech/openai"
+	companyUsecase "github.com/takanoakira/ai-interview-practice/backend/internal/usecase/company"
+	exportUsecase "github.com/takanoakira/ai-interview-practice/backend/internal/usecase/export"
+	importsUsecase "github.com/takanoakira/ai-interview-practice/backend/internal/usecase/imports"
+	interviewEvaluationUsecase "github.com/takanoakira/ai-interview-practice/backend/internal/usecase/interview_evaluation"
+	interviewSessionUsecase "github.com/takanoakira/ai-interview-practice/backend/internal/usecase/interview_session"
+	jobUsecase "github.com/takanoakira/ai-interview-practice/backend/internal/usecase/job"
+	jobPostingUsecase "github.com/takanoakira/ai-interview-practice/backend/internal/usecase/job_posting"
+	jobPostingParseUsecase "github.com/takanoakira/ai-interview-practice/backend/internal/usecase/job_posting_parse"
+	organizationUsecase "github.com/takanoakira/ai-interview-practice/backend/internal/usecase/organization"
+	speechUsecase "github.com/takanoakira/ai-interview-practice/backend/internal/usecase/speech"
+	trashUsecase "github.com/takanoakira/ai-interview-practice/backend/internal/usecase/trash"
+	userUsecase "github.com/takanoakira/ai-interview-practice/backend/internal/usecase/user"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/worker"
 
 	"github.com/takanoakira/ai-interview-practice/backend/internal/routes"
 	"gorm.io/driver/mysql"
@@ -43,31 +89,165 @@ func main() {
 	}
 
 	// リポジトリの初期化
+	transactor := transaction.NewTransactor(db)
 	companyRepository := companyRepo.NewRepository(db)
 	jobPostingRepository := jobPostingRepo.NewRepository(db)
+	interviewSessionRepository := interviewSessionRepo.NewRepository(db)
+	interviewEvaluationRepository := interviewEvaluationRepo.NewRepository(db)
+	jobRepository := jobRepo.NewRepository(db)
+	userRepository := userRepo.NewRepository(db)
+	exportRepository := exportRepo.NewRepository(db)
+	organizationRepository := organizationRepo.NewRepository(db)
 
-	// ハンドラーの初期化
-	companyHandler := company.NewHandler(companyRepository)
-	jobPostingHandler := job_posting.NewHandler(jobPostingRepository)
+	// 認証の初期化
+	verifier, err := newVerifier()
+	if err != nil {
+		log.Fatalf("Failed to initialize authentication: %v", err)
+	}
 
-	// ルーターの設定
-	router := gin.Default()
-
-	// CORSの設定
-	router.Use(func(c *gin.Context) {
-		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
-		c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
-		if c.Request.Method == "OPTIONS" {
-			c.AbortWithStatus(204)
-			return
+	// ジョブキューの初期化
+	jobQueueConfig, err := newJobQueueConfig()
+	if err != nil {
+		log.Fatalf("Failed to load job queue config: %v", err)
+	}
+	jobQueue := worker.NewQueue(jobRepository, jobQueueConfig)
+	evaluationCallTimeout, err := newEvaluationCallTimeout()
+	if err != nil {
+		log.Fatalf("Failed to load job queue config: %v", err)
+	}
+
+	// LLMプロバイダーの初期化
+	llmProvider, err := newLLMProvider()
+	if err != nil {
+		log.Fatalf("Failed to initialize LLM provider: %v", err)
+	}
+
+	// 音声認識・音声合成プロバイダーの初期化
+	speechToText, textToSpeech, err := newSpeechProviders()
+	if err != nil {
+		log.Fatalf("Failed to initialize speech providers: %v", err)
+	}
+
+	// プロンプトテンプレートの読み込み
+	// PROMPT_DIR で組み込みテンプレートを上書きし、PROMPT_VERSION で使用するバージョンを固定できる
+	prompts, err := prompt.NewRegistry(os.Getenv("PROMPT_DIR"), os.Getenv("PROMPT_VERSION"))
+	if err != nil {
+		log.Fatalf("Failed to load prompt templates: %v", err)
+	}
+	var questionGenerator interviewSessionUsecase.QuestionGenerator
+	if llmProvider != nil {
+		questionGenerator = interviewSessionUsecase.NewLLMQuestionGenerator(llmProvider, prompts)
+	} else {
+		questionGenerator = interviewSessionUsecase.NewStaticQuestionGenerator()
+	}
+
+	// ユースケースの初期化
+	companyUseCase := companyUsecase.NewUseCase(companyRepository)
+	jobPostingUseCase := jobPostingUsecase.NewUseCase(jobPostingRepository, companyRepository)
+	interviewSessionUseCase := interviewSessionUsecase.NewUseCase(
+		interviewSessionRepository,
+		companyRepository,
+		jobPostingRepository,
+		questionGenerator,
+	)
+	interviewEvaluationUseCase := interviewEvaluationUsecase.NewUseCase(
+		interviewEvaluationRepository,
+		interviewSessionRepository,
+		companyRepository,
+		jobPostingRepository,
+		llmProvider,
+		prompts,
+		jobQueue,
+		evaluationCallTimeout,
+	)
+	jobUseCase := jobUsecase.NewUseCase(jobRepository)
+	speechUseCase := speechUsecase.NewUseCase(speechToText, textToSpeech)
+	userUseCase := userUsecase.NewUseCase(userRepository)
+	organizationUseCase := organizationUsecase.NewUseCase(organizationRepository)
+	trashConfig, err := newTrashConfig()
+	if err != nil {
+		log.Fatalf("Failed to load trash config: %v", err)
+	}
+	trashUseCase := trashUsecase.NewUseCase(companyRepository, jobPostingRepository, trashConfig.retention)
+	importsUseCase := importsUsecase.NewUseCase(transactor, companyRepository, jobPostingRepository, interviewSessionRepository)
+	exportUseCase := exportUsecase.NewUseCase(transactor, exportRepository)
+	jobPostingParseUseCase := jobPostingParseUsecase.NewUseCase(llmProvider, prompts)
+
+	// ジョブの処理関数を登録してワーカーを起動
+	jobQueue.Register(interviewEvaluationUsecase.JobTypeEvaluation, interviewEvaluationUseCase.HandleEvaluationJob)
+	if err := jobQueue.Start(context.Background()); err != nil {
+		log.Fatalf("Failed to start job queue: %v", err)
+	}
+	defer jobQueue.Stop()
+
+	// 保持期間を過ぎたゴミ箱の企業・求人情報を定期的に完全に削除する
+	trashPurger := worker.NewPeriodic("trash purge", trashConfig.purgeInterval, func(ctx context.Context) error {
+		companies, jobPostings, err := trashUseCase.PurgeExpired(ctx)
+		if companies > 0 || jobPostings > 0 {
+			log.Printf("trash: purged %d company(ies) and %d job posting(s)", companies, jobPostings)
 		}
-		c.Next()
+		return err
 	})
+	trashPurger.Start(context.Background())
+	defer trashPurger.Stop()
+
+	// CORS・音声面接の WebSocket を許可するオリジン（未設定時はフロントエンドの開発サーバーのみ）
+	allowedOrigins := middleware.ParseAllowedOrigins(os.Getenv("CORS_ALLOWED_ORIGINS"))
+	if len(allowedOrigins) == 0 {
+		allowedOrigins = middleware.AllowedOrigins{"http://localhost:3000"}
+	}
+
+	// ハンドラーの初期化
+	companyHandler := company.NewHandler(companyUseCase)
+	jobPostingHandler := job_posting.NewHandler(jobPostingUseCase, jobPostingParseUseCase)
+	interviewSessionHandler := interview_session.NewHandler(interviewSessionUseCase)
+	interviewEvaluationHandler := interview_evaluation.NewHandler(interviewEvaluationUseCase)
+	jobHandler := job.NewHandler(jobUseCase)
+	interviewVoiceHandler := interview_voice.NewHandler(interviewSessionUseCase, speechUseCase, allowedOrigins.CheckOrigin)
+	speechAPIHandler := speechHandler.NewHandler(speechUseCase)
+	userHandler := user.NewHandler(userUseCase)
+	organizationHandler := organization.NewHandler(organizationUseCase)
+	trashHandler := trash.NewHandler(trashUseCase)
+	importHandler := importsHandler.NewHandler(importsUseCase)
+	exportAPIHandler := exportHandler.NewHandler(exportUseCase)
+
+	// ルーターの設定
+	router := gin.New()
+	// アクセスログ（WebSocket のクエリパラメータのアクセストークンは伏せ字にする）とパニックからの復帰
+	router.Use(middleware.Logger(), gin.Recovery())
+
+	// 検証エラーの details にはリクエストの JSON のキーを使用する
+	if validate, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		apperror.UseJSONFieldNames(validate)
+	}
+
+	// CORSの設定（音声面接の WebSocket も同じオリジンに制限する）
+	router.Use(middleware.CORS(allowedOrigins))
+
+	// エラーレスポンスの共通化（以降のミドルウェア・ハンドラーのエラーを処理する）
+	router.Use(middleware.ErrorHandler())
+
+	// 認証（全てのエンドポイントで必須）
+	router.Use(middleware.Auth(verifier, userUseCase))
+	// 組織ワークスペース（X-Organization-ID ヘッダーを指定した場合のみ）
+	router.Use(middleware.Workspace(organizationUseCase))
+
+	// 企業・求人情報の更新・削除の If-Match（IF_MATCH_REQUIRED=true の場合は必須）
+	ifMatch := middleware.RequireIfMatch(os.Getenv("IF_MATCH_REQUIRED") == "true")
 
 	// ハンドラーの登録
-	routes.SetupCompanyRoutes(router, companyHandler)
-	routes.SetupJobPostingRoutes(router, jobPostingHandler)
+	routes.SetupCompanyRoutes(router, companyHandler, ifMatch)
+	routes.SetupJobPostingRoutes(router, jobPostingHandler, ifMatch)
+	routes.SetupInterviewSessionRoutes(router, interviewSessionHandler)
+	routes.SetupInterviewEvaluationRoutes(router, interviewEvaluationHandler)
+	routes.SetupJobRoutes(router, jobHandler)
+	routes.SetupInterviewVoiceRoutes(router, interviewVoiceHandler)
+	routes.SetupSpeechRoutes(router, speechAPIHandler)
+	routes.SetupUserRoutes(router, userHandler)
+	routes.SetupOrganizationRoutes(router, organizationHandler)
+	routes.SetupTrashRoutes(router, trashHandler)
+	routes.SetupImportRoutes(router, importHandler)
+	routes.SetupExportRoutes(router, exportAPIHandler)
 
 	// サーバーの起動
 	port := os.Getenv("PORT")
@@ -79,3 +259,168 @@ func main() {
 		log.Fatalf("Failed to start server: %v", err)
 	}
 }
+
+// newLLMProvider は環境変数 LLM_PROVIDER に応じて LLM プロバイダーを生成します。
+// 未設定の場合は nil を返し、固定文言の質問を使用します。
+func newLLMProvider() (llm.Provider, error) {
+	switch os.Getenv("LLM_PROVIDER") {
+	case "":
+		return nil, nil
+	case "openai":
+		timeout := 30 * time.Second
+		if v := os.Getenv("LLM_TIMEOUT_SECONDS"); v != "" {
+			seconds, err := strconv.Atoi(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid LLM_TIMEOUT_SECONDS: %w", err)
+			}
+			timeout = time.Duration(seconds) * time.Second
+		}
+		return openai.NewProvider(openai.Config{
+			BaseURL: os.Getenv("LLM_BASE_URL"),
+			APIKey:  os.Getenv("LLM_API_KEY"),
+			Model:   os.Getenv("LLM_MODEL"),
+			Timeout: timeout,
+		}), nil
+	case "fake":
+		// LLM_FAKE_FIXTURES_DIR が指定されていればそのフィクスチャを再生する
+		if dir := os.Getenv("LLM_FAKE_FIXTURES_DIR"); dir != "" {
+			fixtures, err := fake.LoadFixtures(dir)
+			if err != nil {
+				return nil, err
+			}
+			return fake.NewProvider(fixtures), nil
+		}
+		return fake.NewDefaultProvider()
+	default:
+		return nil, fmt.Errorf("unknown LLM_PROVIDER: %s", os.Getenv("LLM_PROVIDER"))
+	}
+}
+
+// newVerifier は環境変数 AUTH_MODE に応じてトークンの検証方法を生成します。
+// jwks: AUTH_JWKS_URL（未設定時は AUTH_ISSUER の /.well-known/jwks.json）から公開鍵を取得（Auth0 互換）
+// static: AUTH_PUBLIC_KEY_FILE の公開鍵で検証（開発・テスト用。トークンは cmd/devtoken で発行）
+func newVerifier() (auth.Verifier, error) {
+	config := auth.VerifierConfig{
+		Issuer:   os.Getenv("AUTH_ISSUER"),
+		Audience: os.Getenv("AUTH_AUDIENCE"),
+	}
+	switch os.Getenv("AUTH_MODE") {
+	case "jwks":
+		jwksURL := os.Getenv("AUTH_JWKS_URL")
+		if jwksURL == "" {
+			if config.Issuer == "" {
+				return nil, fmt.Errorf("AUTH_JWKS_URL or AUTH_ISSUER is required when AUTH_MODE=jwks")
+			}
+			jwksURL = strings.TrimRight(config.Issuer, "/") + "/.well-known/jwks.json"
+		}
+		return auth.NewJWKSVerifier(jwksURL, config), nil
+	case "static":
+		publicKey, err := auth.LoadRSAPublicKey(os.Getenv("AUTH_PUBLIC_KEY_FILE"))
+		if err != nil {
+			return nil, fmt.Errorf("load AUTH_PUBLIC_KEY_FILE: %w", err)
+		}
+		return auth.NewStaticKeyVerifier(publicKey, config), nil
+	default:
+		return nil, fmt.Errorf("AUTH_MODE must be jwks or static: %q", os.Getenv("AUTH_MODE"))
+	}
+}
+
+// newSpeechProviders は環境変数 SPEECH_PROVIDER に応じて音声認識・音声合成プロバイダーを生成します。
+// 未設定の場合は nil を返し、音声機能は利用できません。
+func newSpeechProviders() (speech.SpeechToText, speech.TextToSpeech, error) {
+	switch os.Getenv("SPEECH_PROVIDER") {
+	case "":
+		return nil, nil, nil
+	case "openai":
+		timeout := 60 * time.Second
+		if v := os.Getenv("SPEECH_TIMEOUT_SECONDS"); v != "" {
+			seconds, err := strconv.Atoi(v)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid SPEECH_TIMEOUT_SECONDS: %w", err)
+			}
+			timeout = time.Duration(seconds) * time.Second
+		}
+		config := openaiSpeech.Config{
+			BaseURL:  os.Getenv("SPEECH_BASE_URL"),
+			APIKey:   os.Getenv("SPEECH_API_KEY"),
+			STTModel: os.Getenv("SPEECH_STT_MODEL"),
+			TTSModel: os.Getenv("SPEECH_TTS_MODEL"),
+			TTSVoice: os.Getenv("SPEECH_TTS_VOICE"),
+			Timeout:  timeout,
+		}
+		return openaiSpeech.NewSpeechToText(config), openaiSpeech.NewTextToSpeech(config), nil
+	case "fake":
+		return fakeSpeech.NewSpeechToText(), fakeSpeech.NewTextToSpeech(), nil
+	default:
+		return nil, nil, fmt.Errorf("unknown SPEECH_PROVIDER: %s", os.Getenv("SPEECH_PROVIDER"))
+	}
+}
+
+// newJobQueueConfig は環境変数 JOB_WORKERS / JOB_TIMEOUT_SECONDS / JOB_MAX_RETRIES でジョブキューの既定値を上書きします
+func newJobQueueConfig() (worker.Config, error) {
+	config := worker.DefaultConfig()
+	if v := os.Getenv("JOB_WORKERS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return config, fmt.Errorf("invalid JOB_WORKERS: %s", v)
+		}
+		config.Workers = n
+	}
+	if v := os.Getenv("JOB_TIMEOUT_SECONDS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return config, fmt.Errorf("invalid JOB_TIMEOUT_SECONDS: %s", v)
+		}
+		config.DefaultTimeout = time.Duration(n) * time.Second
+	}
+	if v := os.Getenv("JOB_MAX_RETRIES"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return config, fmt.Errorf("invalid JOB_MAX_RETRIES: %s", v)
+		}
+		config.MaxRetries = n
+	}
+	return config, nil
+}
+
+// newEvaluationCallTimeout は環境変数 EVALUATION_CALL_TIMEOUT_SECONDS で評価生成の LLM 呼び出し1回あたりの時間を上書きします
+func newEvaluationCallTimeout() (time.Duration, error) {
+	v := os.Getenv("EVALUATION_CALL_TIMEOUT_SECONDS")
+	if v == "" {
+		return interviewEvaluationUsecase.DefaultCallTimeout, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("invalid EVALUATION_CALL_TIMEOUT_SECONDS: %s", v)
+	}
+	return time.Duration(n) * time.Second, nil
+}
+
+// trashConfig はゴミ箱の保持期間と完全削除の実行間隔です
+type trashConfig struct {
+	retention     time.Duration
+	purgeInterval time.Duration
+}
+
+// newTrashConfig は環境変数 TRASH_RETENTION_DAYS / TRASH_PURGE_INTERVAL_MINUTES でゴミ箱の既定値を上書きします
+func newTrashConfig() (trashConfig, error) {
+	config := trashConfig{
+		retention:     trashUsecase.DefaultRetention,
+		purgeInterval: time.Hour,
+	}
+	if v := os.Getenv("TRASH_RETENTION_DAYS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return config, fmt.Errorf("invalid TRASH_RETENTION_DAYS: %s", v)
+		}
+		config.retention = time.Duration(n) * 24 * time.Hour
+	}
+	if v := os.Getenv("TRASH_PURGE_INTERVAL_MINUTES"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return config, fmt.Errorf("invalid TRASH_PURGE_INTERVAL_MINUTES: %s", v)
+		}
+		config.purgeInterval = time.Duration(n) * time.Minute
+	}
+	return config, nil
+}
@@ -0,0 +1,213 @@
+// dedupe はワークスペース内で同名の企業、企業内で同じタイトルの求人を検出し、最も古いレコードに統合します。
+// 一意制約のマイグレーション（000019・000020）を適用する前に実行してください。
+//
+//	go run ./cmd/dedupe          # 統合内容の確認のみ（変更はロールバックされます）
+//	go run ./cmd/dedupe -apply   # 統合を実行
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/joho/godotenv"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// errDryRun は確認のみの実行でトランザクションをロールバックするためのエラーです
+var errDryRun = errors.New("dry run")
+
+// workspaceExpr は企業のワークスペースを表す式です（マイグレーション 000019 の workspace_key と同じ）
+const workspaceExpr = "IF(%[1]s.organization_id IS NULL, CONCAT('user:', %[1]s.owner_user_id), CONCAT('org:', %[1]s.organization_id))"
+
+// duplicate は統合元（DuplicateID）と統合先（KeepID）の組です
+type duplicate struct {
+	DuplicateID int
+	KeepID      int
+}
+
+func main() {
+	godotenv.Load()
+
+	apply := flag.Bool("apply", false, "統合を実行する（指定しない場合は確認のみ）")
+	flag.Parse()
+
+	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
+		os.Getenv("MYSQL_USER"),
+		os.Getenv("MYSQL_PASSWORD"),
+		os.Getenv("DB_HOST"),
+		os.Getenv("DB_PORT"),
+		os.Getenv("MYSQL_DATABASE"),
+	)
+	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Warn)})
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+
+	var companies, jobPostings int
+	err = db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if companies, err = mergeCompanies(tx); err != nil {
+			return err
+		}
+		if jobPostings, err = mergeDuplicateJobPostings(tx); err != nil {
+			return err
+		}
+		if !*apply {
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		log.Fatalf("Failed to merge duplicates: %v", err)
+	}
+
+	if *apply {
+		log.Printf("Merged %d companies and %d job postings", companies, jobPostings)
+	} else {
+		log.Printf("Dry run: %d companies and %d job postings would be merged (run with -apply to merge)", companies, jobPostings)
+	}
+}
+
+// mergeCompanies は同じワークスペースで同名の企業を最も古い企業に統合し、統合した企業数を返します
+func mergeCompanies(tx *gorm.DB) (int, error) {
+	var duplicates []duplicate
+	query := fmt.Sprintf(`
+		SELECT c.id AS duplicate_id, k.keep_id
+		FROM companies c
+		JOIN (
+			SELECT %s AS workspace, name, MIN(id) AS keep_id
+			FROM companies
+			WHERE owner_user_id IS NOT NULL
+			GROUP BY workspace, name
+			HAVING COUNT(*) > 1
+		) k ON %s = k.workspace AND c.name = k.name
+		WHERE c.id <> k.keep_id
+		ORDER BY k.keep_id, c.id`, fmt.Sprintf(workspaceExpr, "companies"), fmt.Sprintf(workspaceExpr, "c"))
+	if err := tx.Raw(query).Scan(&duplicates).Error; err != nil {
+		return 0, err
+	}
+
+	for _, d := range duplicates {
+		log.Printf("company %d -> %d", d.DuplicateID, d.KeepID)
+		if err := mergeCompany(tx, d); err != nil {
+			return 0, fmt.Errorf("merge company %d into %d: %w", d.DuplicateID, d.KeepID, err)
+		}
+	}
+	return len(duplicates), nil
+}
+
+func mergeCompany(tx *gorm.DB, d duplicate) error {
+	// 統合先の事業内容が空の場合は統合元の内容を引き継ぐ
+	if err := tx.Exec(`
+		UPDATE companies k JOIN companies d ON d.id = ?
+		SET k.business_description = d.business_description
+		WHERE k.id = ? AND (k.business_description IS NULL OR k.business_description = '')`,
+		d.DuplicateID, d.KeepID).Error; err != nil {
+		return err
+	}
+
+	// 同じ項目名・内容の追加情報は統合先のものを残し、それ以外は統合先に移す
+	if err := tx.Exec(`
+		DELETE d FROM company_custom_fields d
+		JOIN company_custom_fields k ON k.company_id = ? AND k.field_name = d.field_name AND k.content = d.content
+		WHERE d.company_id = ?`, d.KeepID, d.DuplicateID).Error; err != nil {
+		return err
+	}
+	if err := tx.Exec("UPDATE company_custom_fields SET company_id = ? WHERE company_id = ?", d.KeepID, d.DuplicateID).Error; err != nil {
+		return err
+	}
+
+	// 統合先に同じタイトルの求人がある場合は求人も統合し、残りは統合先に移す
+	var jobDuplicates []duplicate
+	if err := tx.Raw(`
+		SELECT d.id AS duplicate_id, MIN(k.id) AS keep_id
+		FROM job_postings d
+		JOIN job_postings k ON k.company_id = ? AND k.title = d.title
+		WHERE d.company_id = ?
+		GROUP BY d.id
+		ORDER BY d.id`, d.KeepID, d.DuplicateID).Scan(&jobDuplicates).Error; err != nil {
+		return err
+	}
+	for _, jd := range jobDuplicates {
+		log.Printf("  job posting %d -> %d", jd.DuplicateID, jd.KeepID)
+		if err := mergeJobPosting(tx, jd); err != nil {
+			return fmt.Errorf("merge job posting %d into %d: %w", jd.DuplicateID, jd.KeepID, err)
+		}
+	}
+	if err := tx.Exec("UPDATE job_postings SET company_id = ? WHERE company_id = ?", d.KeepID, d.DuplicateID).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Exec("UPDATE interview_sessions SET company_id = ? WHERE company_id = ?", d.KeepID, d.DuplicateID).Error; err != nil {
+		return err
+	}
+	if err := tx.Exec("UPDATE job_posting_moves SET from_company_id = ? WHERE from_company_id = ?", d.KeepID, d.DuplicateID).Error; err != nil {
+		return err
+	}
+	if err := tx.Exec("UPDATE job_posting_moves SET to_company_id = ? WHERE to_company_id = ?", d.KeepID, d.DuplicateID).Error; err != nil {
+		return err
+	}
+
+	return tx.Exec("DELETE FROM companies WHERE id = ?", d.DuplicateID).Error
+}
+
+// mergeDuplicateJobPostings は同じ企業で同じタイトルの求人を最も古い求人に統合し、統合した求人数を返します
+func mergeDuplicateJobPostings(tx *gorm.DB) (int, error) {
+	var duplicates []duplicate
+	if err := tx.Raw(`
+		SELECT j.id AS duplicate_id, k.keep_id
+		FROM job_postings j
+		JOIN (
+			SELECT company_id, title, MIN(id) AS keep_id
+			FROM job_postings
+			GROUP BY company_id, title
+			HAVING COUNT(*) > 1
+		) k ON j.company_id = k.company_id AND j.title = k.title
+		WHERE j.id <> k.keep_id
+		ORDER BY k.keep_id, j.id`).Scan(&duplicates).Error; err != nil {
+		return 0, err
+	}
+
+	for _, d := range duplicates {
+		log.Printf("job posting %d -> %d", d.DuplicateID, d.KeepID)
+		if err := mergeJobPosting(tx, d); err != nil {
+			return 0, fmt.Errorf("merge job posting %d into %d: %w", d.DuplicateID, d.KeepID, err)
+		}
+	}
+	return len(duplicates), nil
+}
+
+func mergeJobPosting(tx *gorm.DB, d duplicate) error {
+	// 統合先の仕事内容が空の場合は統合元の内容を引き継ぐ
+	if err := tx.Exec(`
+		UPDATE job_postings k JOIN job_postings d ON d.id = ?
+		SET k.description = d.description
+		WHERE k.id = ? AND (k.description IS NULL OR k.description = '')`,
+		d.DuplicateID, d.KeepID).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Exec(`
+		DELETE d FROM job_custom_fields d
+		JOIN job_custom_fields k ON k.job_id = ? AND k.field_name = d.field_name AND k.content = d.content
+		WHERE d.job_id = ?`, d.KeepID, d.DuplicateID).Error; err != nil {
+		return err
+	}
+	if err := tx.Exec("UPDATE job_custom_fields SET job_id = ? WHERE job_id = ?", d.KeepID, d.DuplicateID).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Exec("UPDATE interview_sessions SET job_posting_id = ? WHERE job_posting_id = ?", d.KeepID, d.DuplicateID).Error; err != nil {
+		return err
+	}
+	if err := tx.Exec("UPDATE job_posting_moves SET job_posting_id = ? WHERE job_posting_id = ?", d.KeepID, d.DuplicateID).Error; err != nil {
+		return err
+	}
+
+	return tx.Exec("DELETE FROM job_postings WHERE id = ?", d.DuplicateID).Error
+}
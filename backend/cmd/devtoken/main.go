@@ -0,0 +1,103 @@
+// devtoken は AUTH_MODE=static の開発・テスト環境で使用するアクセストークンを発行します。
+//
+//	go run ./cmd/devtoken -generate           # 鍵ペアを生成
+//	go run ./cmd/devtoken -sub user-a         # user-a のトークンを発行
+package main
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/joho/godotenv"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/auth"
+)
+
+func main() {
+	godotenv.Load()
+
+	generate := flag.Bool("generate", false, "鍵ペアを生成して AUTH_PRIVATE_KEY_FILE / AUTH_PUBLIC_KEY_FILE に保存する")
+	subject := flag.String("sub", "dev-user", "sub クレーム")
+	email := flag.String("email", "", "email クレーム")
+	emailVerified := flag.Bool("email-verified", true, "email_verified クレーム（-email を指定した場合のみ）")
+	name := flag.String("name", "", "name クレーム")
+	ttl := flag.Duration("ttl", 24*time.Hour, "有効期間")
+	flag.Parse()
+
+	privateKeyFile := os.Getenv("AUTH_PRIVATE_KEY_FILE")
+	publicKeyFile := os.Getenv("AUTH_PUBLIC_KEY_FILE")
+	if privateKeyFile == "" || publicKeyFile == "" {
+		log.Fatal("AUTH_PRIVATE_KEY_FILE and AUTH_PUBLIC_KEY_FILE are required")
+	}
+
+	if *generate {
+		if err := generateKeyPair(privateKeyFile, publicKeyFile); err != nil {
+			log.Fatalf("Failed to generate key pair: %v", err)
+		}
+		log.Printf("Generated %s and %s", privateKeyFile, publicKeyFile)
+		return
+	}
+
+	privateKey, err := auth.LoadRSAPrivateKey(privateKeyFile)
+	if err != nil {
+		log.Fatalf("Failed to load private key: %v", err)
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"sub": *subject,
+		"iat": now.Unix(),
+		"exp": now.Add(*ttl).Unix(),
+	}
+	if v := os.Getenv("AUTH_ISSUER"); v != "" {
+		claims["iss"] = v
+	}
+	if v := os.Getenv("AUTH_AUDIENCE"); v != "" {
+		claims["aud"] = v
+	}
+	if *email != "" {
+		claims["email"] = *email
+		claims["email_verified"] = *emailVerified
+	}
+	if *name != "" {
+		claims["name"] = *name
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(privateKey)
+	if err != nil {
+		log.Fatalf("Failed to sign token: %v", err)
+	}
+	fmt.Println(token)
+}
+
+func generateKeyPair(privateKeyFile, publicKeyFile string) error {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return err
+	}
+	publicDER, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		return err
+	}
+
+	if err := writePEM(privateKeyFile, "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(key), 0o600); err != nil {
+		return err
+	}
+	return writePEM(publicKeyFile, "PUBLIC KEY", publicDER, 0o644)
+}
+
+func writePEM(path, blockType string, der []byte, perm os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), perm)
+}
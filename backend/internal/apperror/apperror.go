@@ -0,0 +1,198 @@
+// Package apperror はアプリケーション全体で使用するエラーの種別を定義します。
+// ハンドラーは c.Error でエラーを登録し、middleware.ErrorHandler が種別に応じたステータスと共通のエラーレスポンス形式で返します。
+package apperror
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Code はエラーレスポンスの code に設定されるエラーの種別です
+type Code string
+
+const (
+	CodeBadRequest           Code = "BAD_REQUEST"
+	CodeValidation           Code = "VALIDATION_ERROR"
+	CodeUnauthorized         Code = "UNAUTHORIZED"
+	CodeForbidden            Code = "FORBIDDEN"
+	CodeNotFound             Code = "NOT_FOUND"
+	CodeConflict             Code = "CONFLICT"
+	CodeDuplicate            Code = "DUPLICATE"
+	CodeUnprocessable        Code = "UNPROCESSABLE_ENTITY"
+	CodePreconditionFailed   Code = "PRECONDITION_FAILED"
+	CodePreconditionRequired Code = "PRECONDITION_REQUIRED"
+	CodePayloadTooLarge      Code = "PAYLOAD_TOO_LARGE"
+	CodeUnsupportedMediaType Code = "UNSUPPORTED_MEDIA_TYPE"
+	CodeUpstreamError        Code = "UPSTREAM_ERROR"
+	CodeUpstreamUnavailable  Code = "UPSTREAM_UNAVAILABLE"
+	CodeTimeout              Code = "TIMEOUT"
+	CodeInternal             Code = "INTERNAL_ERROR"
+)
+
+// statuses は種別ごとの HTTP ステータスです
+var statuses = map[Code]int{
+	CodeBadRequest:           http.StatusBadRequest,
+	CodeValidation:           http.StatusBadRequest,
+	CodeUnauthorized:         http.StatusUnauthorized,
+	CodeForbidden:            http.StatusForbidden,
+	CodeNotFound:             http.StatusNotFound,
+	CodeConflict:             http.StatusConflict,
+	CodeDuplicate:            http.StatusConflict,
+	CodeUnprocessable:        http.StatusUnprocessableEntity,
+	CodePreconditionFailed:   http.StatusPreconditionFailed,
+	CodePreconditionRequired: http.StatusPreconditionRequired,
+	CodePayloadTooLarge:      http.StatusRequestEntityTooLarge,
+	CodeUnsupportedMediaType: http.StatusUnsupportedMediaType,
+	CodeUpstreamError:        http.StatusBadGateway,
+	CodeUpstreamUnavailable:  http.StatusServiceUnavailable,
+	CodeTimeout:              http.StatusGatewayTimeout,
+	CodeInternal:             http.StatusInternalServerError,
+}
+
+// Detail はフィールド単位のエラーの詳細です
+type Detail struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Error は種別を持つアプリケーションのエラーです。JSON はエラーレスポンスの error オブジェクトになります。
+type Error struct {
+	Code    Code     `json:"code"`
+	Message string   `json:"message"`
+	Details []Detail `json:"details,omitempty"`
+	// status は種別と異なる HTTP ステータスを返す場合に設定します
+	status int
+	// cause は原因となったエラーです。レスポンスには含まれません。
+	cause error
+}
+
+// New は種別とメッセージを指定してエラーを生成します
+func New(code Code, message string, details ...Detail) *Error {
+	return &Error{Code: code, Message: message, Details: details}
+}
+
+func (e *Error) Error() string {
+	if e.cause != nil {
+		return e.Message + ": " + e.cause.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// Status はエラーに対応する HTTP ステータスを返します
+func (e *Error) Status() int {
+	if e.status != 0 {
+		return e.status
+	}
+	if status, ok := statuses[e.Code]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// Wrap は原因となったエラーを保持したコピーを返します。
+// errors.Is・errors.As は原因のエラーにも一致します。
+func (e *Error) Wrap(cause error) *Error {
+	copied := *e
+	copied.cause = cause
+	return &copied
+}
+
+// WithStatus は HTTP ステータスを変更したコピーを返します
+func (e *Error) WithStatus(status int) *Error {
+	copied := *e
+	copied.status = status
+	return &copied
+}
+
+func NotFound(message string) *Error {
+	return New(CodeNotFound, message)
+}
+
+func BadRequest(message string) *Error {
+	return New(CodeBadRequest, message)
+}
+
+func Validation(message string, details ...Detail) *Error {
+	return New(CodeValidation, message, details...)
+}
+
+func Conflict(message string) *Error {
+	return New(CodeConflict, message)
+}
+
+// Duplicate は一意であるべき値（企業名など）が既に使用されている場合のエラーです
+func Duplicate(message string, details ...Detail) *Error {
+	return New(CodeDuplicate, message, details...)
+}
+
+// Unprocessable は形式は正しいが、参照先が存在しないなど内容を処理できない場合のエラーです
+func Unprocessable(message string, details ...Detail) *Error {
+	return New(CodeUnprocessable, message, details...)
+}
+
+// PreconditionFailed は If-Match の ETag が現在のリソースと一致しない場合のエラーです
+func PreconditionFailed(message string) *Error {
+	return New(CodePreconditionFailed, message)
+}
+
+// PreconditionRequired は更新に必要な If-Match ヘッダーが指定されていない場合のエラーです
+func PreconditionRequired(message string) *Error {
+	return New(CodePreconditionRequired, message)
+}
+
+func Unauthorized(message string) *Error {
+	return New(CodeUnauthorized, message)
+}
+
+func Forbidden(message string) *Error {
+	return New(CodeForbidden, message)
+}
+
+func PayloadTooLarge(message string) *Error {
+	return New(CodePayloadTooLarge, message)
+}
+
+func UpstreamError(message string) *Error {
+	return New(CodeUpstreamError, message)
+}
+
+func UpstreamUnavailable(message string) *Error {
+	return New(CodeUpstreamUnavailable, message)
+}
+
+func Timeout(message string) *Error {
+	return New(CodeTimeout, message)
+}
+
+// InvalidParameter はパスパラメータの形式が不正な場合のエラーです
+func InvalidParameter(name string) *Error {
+	return Validation("invalid "+name+" parameter", Detail{Field: name, Message: "must be an integer"})
+}
+
+// From は err をアプリケーションのエラーに変換します。
+// 種別を持たないエラーは、期限切れ・キャンセルを除き内部エラーとして扱い、メッセージを公開しません。
+func From(err error) *Error {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return Timeout("request timed out").Wrap(err)
+	case errors.Is(err, context.Canceled):
+		// クライアントの切断によるキャンセル（nginx と同じく 499 を返す）
+		return New(CodeBadRequest, "request canceled").WithStatus(499).Wrap(err)
+	}
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		return fromValidationErrors(validationErrs)
+	}
+	return New(CodeInternal, "internal server error").Wrap(err)
+}
@@ -0,0 +1,109 @@
+package apperror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// UseJSONFieldNames は検証エラーのフィールド名に JSON のキーを使用するよう validate を設定します
+func UseJSONFieldNames(validate *validator.Validate) {
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return field.Name
+		}
+		return name
+	})
+}
+
+// FromBinding はリクエストの解析・検証（ShouldBindJSON など）のエラーを変換します
+func FromBinding(err error) *Error {
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		return fromValidationErrors(validationErrs)
+	}
+
+	var maxBytesErr *http.MaxBytesError
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &maxBytesErr):
+		return PayloadTooLarge("request body is too large").Wrap(err)
+	case errors.As(err, &typeErr):
+		field := typeErr.Field
+		if field == "" {
+			field = "body"
+		}
+		return Validation("request body is invalid", Detail{
+			Field:   field,
+			Message: fmt.Sprintf("must be of type %s", typeErr.Type.Kind()),
+		}).Wrap(err)
+	case errors.As(err, &syntaxErr), errors.Is(err, io.ErrUnexpectedEOF):
+		return BadRequest("request body is not valid JSON").Wrap(err)
+	case errors.Is(err, io.EOF):
+		return BadRequest("request body is empty").Wrap(err)
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		// DisallowUnknownFields による未定義のフィールドのエラー（json パッケージは型を公開していない）
+		field := strings.Trim(strings.TrimPrefix(err.Error(), "json: unknown field "), `"`)
+		return Validation("request body contains unknown field", Detail{Field: field, Message: "is not allowed"}).Wrap(err)
+	default:
+		return BadRequest(err.Error()).Wrap(err)
+	}
+}
+
+func fromValidationErrors(errs validator.ValidationErrors) *Error {
+	details := make([]Detail, 0, len(errs))
+	for _, fe := range errs {
+		details = append(details, Detail{Field: fieldPath(fe), Message: fieldMessage(fe)})
+	}
+	return Validation("validation failed", details...).Wrap(errs)
+}
+
+// fieldPath は先頭の構造体名を除いたフィールドのパス（例: custom_fields[0].field_name）を返します
+func fieldPath(fe validator.FieldError) string {
+	namespace := fe.Namespace()
+	if _, rest, ok := strings.Cut(namespace, "."); ok {
+		return rest
+	}
+	return namespace
+}
+
+func fieldMessage(fe validator.FieldError) string {
+	isString := fe.Kind() == reflect.String
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "max":
+		if isString {
+			return fmt.Sprintf("must be at most %s characters", fe.Param())
+		}
+		if fe.Kind() == reflect.Slice || fe.Kind() == reflect.Map {
+			return fmt.Sprintf("must contain at most %s items", fe.Param())
+		}
+		return fmt.Sprintf("must be less than or equal to %s", fe.Param())
+	case "min":
+		if isString {
+			return fmt.Sprintf("must be at least %s characters", fe.Param())
+		}
+		if fe.Kind() == reflect.Slice || fe.Kind() == reflect.Map {
+			return fmt.Sprintf("must contain at least %s items", fe.Param())
+		}
+		return fmt.Sprintf("must be greater than or equal to %s", fe.Param())
+	case "oneof":
+		return fmt.Sprintf("must be one of [%s]", strings.ReplaceAll(fe.Param(), " ", ", "))
+	case "email":
+		return "must be a valid email address"
+	default:
+		return fmt.Sprintf("failed on the '%s' rule", fe.Tag())
+	}
+}
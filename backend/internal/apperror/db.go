@@ -0,0 +1,52 @@
+package apperror
+
+import (
+	"errors"
+
+	"github.com/go-sql-driver/mysql"
+	"gorm.io/gorm"
+)
+
+// MySQL のエラー番号
+const (
+	mysqlErrDuplicateEntry     = 1062
+	mysqlErrRowIsReferenced    = 1451
+	mysqlErrNoReferencedRow    = 1452
+	mysqlErrRowIsReferencedOld = 1217
+	mysqlErrNoReferencedRowOld = 1216
+)
+
+// FromDB はデータベースのエラーを変換します。
+// レコードが存在しない場合は notFoundMessage を持つ NotFound、一意制約の違反は Duplicate、外部キー制約の違反は Conflict になります。
+// 原因のエラーは保持されるため、errors.Is(err, gorm.ErrRecordNotFound) による判定も引き続き使用できます。
+func FromDB(err error, notFoundMessage string) error {
+	if err == nil {
+		return nil
+	}
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return err
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return NotFound(notFoundMessage).Wrap(err)
+	}
+
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		switch mysqlErr.Number {
+		case mysqlErrDuplicateEntry:
+			return Duplicate("resource already exists").Wrap(err)
+		case mysqlErrRowIsReferenced, mysqlErrRowIsReferencedOld:
+			return Conflict("resource is referenced by other resources").Wrap(err)
+		case mysqlErrNoReferencedRow, mysqlErrNoReferencedRowOld:
+			return Conflict("referenced resource does not exist").Wrap(err)
+		}
+	}
+	return err
+}
+
+// IsDuplicateEntry は err が一意制約違反かどうかを返します
+func IsDuplicateEntry(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrDuplicateEntry
+}
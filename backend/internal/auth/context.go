@@ -0,0 +1,48 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/apperror"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
+)
+
+// ErrUnauthenticated はリクエストに認証済みのユーザーが存在しない場合に返されます
+var ErrUnauthenticated = apperror.Unauthorized("authentication required")
+
+type userContextKey struct{}
+
+// WithUser は認証済みのユーザーを ctx に格納します
+func WithUser(ctx context.Context, user *entity.User) context.Context {
+	return context.WithValue(ctx, userContextKey{}, user)
+}
+
+// UserFromContext は ctx に格納された認証済みのユーザーを返します
+func UserFromContext(ctx context.Context) (*entity.User, bool) {
+	user, ok := ctx.Value(userContextKey{}).(*entity.User)
+	return user, ok && user != nil
+}
+
+// UserIDFromContext は ctx に格納された認証済みのユーザーの ID を返します。
+// ユーザーが存在しない場合は ErrUnauthenticated を返します。
+func UserIDFromContext(ctx context.Context) (int, error) {
+	user, ok := UserFromContext(ctx)
+	if !ok {
+		return 0, ErrUnauthenticated
+	}
+	return user.ID, nil
+}
+
+type membershipContextKey struct{}
+
+// WithMembership はリクエスト対象の組織ワークスペースでのユーザーの所属を ctx に格納します
+func WithMembership(ctx context.Context, membership *entity.Membership) context.Context {
+	return context.WithValue(ctx, membershipContextKey{}, membership)
+}
+
+// MembershipFromContext は ctx に格納された組織への所属を返します。
+// 個人のワークスペースへのリクエストの場合は false を返します。
+func MembershipFromContext(ctx context.Context) (*entity.Membership, bool) {
+	membership, ok := ctx.Value(membershipContextKey{}).(*entity.Membership)
+	return membership, ok && membership != nil
+}
@@ -0,0 +1,110 @@
+package auth
+
+import (
+	"context"
+	"crypto/rsa"
+	"fmt"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/apperror"
+)
+
+// ErrInvalidToken はトークンの署名・有効期限・発行者・対象者のいずれかが不正な場合に返されます
+var ErrInvalidToken = apperror.Unauthorized("invalid token")
+
+// Claims は検証済みのトークンから取り出したユーザー情報です
+type Claims struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// Verifier はアクセストークンを検証します
+type Verifier interface {
+	Verify(ctx context.Context, token string) (*Claims, error)
+}
+
+// VerifierConfig はトークン検証の共通設定です。空の項目は検証しません。
+type VerifierConfig struct {
+	Issuer   string
+	Audience string
+}
+
+// tokenClaims は RS256 トークンのクレームです（Auth0 の ID トークン・アクセストークンに対応）
+type tokenClaims struct {
+	jwt.RegisteredClaims
+	Email         string `json:"email,omitempty"`
+	EmailVerified bool   `json:"email_verified,omitempty"`
+	Name          string `json:"name,omitempty"`
+}
+
+type keyFunc func(ctx context.Context, kid string) (*rsa.PublicKey, error)
+
+type verifier struct {
+	config VerifierConfig
+	key    keyFunc
+}
+
+// NewStaticKeyVerifier は固定の RSA 公開鍵で RS256 トークンを検証する Verifier を生成します（開発・テスト用）
+func NewStaticKeyVerifier(publicKey *rsa.PublicKey, config VerifierConfig) Verifier {
+	return &verifier{
+		config: config,
+		key: func(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+			return publicKey, nil
+		},
+	}
+}
+
+// NewJWKSVerifier は JWKS URL から取得した公開鍵で RS256 トークンを検証する Verifier を生成します
+func NewJWKSVerifier(jwksURL string, config VerifierConfig) Verifier {
+	keys := newJWKSCache(jwksURL)
+	return &verifier{config: config, key: keys.key}
+}
+
+// LoadRSAPublicKey は PEM 形式の RSA 公開鍵ファイルを読み込みます
+func LoadRSAPublicKey(path string) (*rsa.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return jwt.ParseRSAPublicKeyFromPEM(data)
+}
+
+// LoadRSAPrivateKey は PEM 形式の RSA 秘密鍵ファイルを読み込みます
+func LoadRSAPrivateKey(path string) (*rsa.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return jwt.ParseRSAPrivateKeyFromPEM(data)
+}
+
+func (v *verifier) Verify(ctx context.Context, token string) (*Claims, error) {
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}),
+		jwt.WithExpirationRequired(),
+	}
+	if v.config.Issuer != "" {
+		options = append(options, jwt.WithIssuer(v.config.Issuer))
+	}
+	if v.config.Audience != "" {
+		options = append(options, jwt.WithAudience(v.config.Audience))
+	}
+
+	var claims tokenClaims
+	_, err := jwt.ParseWithClaims(token, &claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		return v.key(ctx, kid)
+	}, options...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: sub claim is required", ErrInvalidToken)
+	}
+
+	return &Claims{Subject: claims.Subject, Email: claims.Email, EmailVerified: claims.EmailVerified, Name: claims.Name}, nil
+}
@@ -1,16 +1,25 @@
 package entity
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 // Company は企業情報を表すエンティティです
 type Company struct {
 	ID                  int                  `json:"id" gorm:"primaryKey"`
+	OwnerUserID         int                  `json:"owner_user_id"`
+	OrganizationID      *int                 `json:"organization_id"`
 	Name                string               `json:"name" gorm:"not null"`
 	BusinessDescription *string              `json:"business_description" gorm:"type:text"`
+	Version             int                  `json:"version" gorm:"not null;default:1"`
 	CustomFields        []CompanyCustomField `json:"custom_fields" gorm:"foreignKey:CompanyID"`
 	JobPostings         []JobPosting         `json:"job_postings" gorm:"foreignKey:CompanyID"`
 	CreatedAt           time.Time            `json:"created_at" gorm:"not null;default:CURRENT_TIMESTAMP"`
 	UpdatedAt           time.Time            `json:"updated_at" gorm:"not null;default:CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP"`
+	// DeletedAt はゴミ箱に移動した日時です。削除済みの企業は通常の検索の対象になりません。
+	DeletedAt gorm.DeletedAt `json:"deleted_at" gorm:"index"`
 }
 
 // CompanyCustomField は企業の追加情報を表すエンティティです
@@ -26,7 +35,33 @@ type CompanyCustomField struct {
 // CompanyResponse は企業情報のレスポンス形式を表します
 type CompanyResponse struct {
 	Companies []Company `json:"companies"`
-	Total     int       `json:"total"`
-	Page      int       `json:"page"`
-	Limit     int       `json:"limit"`
+	Pagination
+}
+
+// CompanySummary は企業一覧の軽量な表現です（一覧のカード表示など）。
+// 関連する情報は件数のみを持ち、リクエストの fields で指定した項目のみ値を設定します。
+type CompanySummary struct {
+	ID                  int        `json:"id"`
+	Name                *string    `json:"name,omitempty"`
+	BusinessDescription *string    `json:"business_description,omitempty"`
+	JobPostingCount     *int       `json:"job_posting_count,omitempty"`
+	CustomFieldCount    *int       `json:"custom_field_count,omitempty"`
+	Version             *int       `json:"version,omitempty"`
+	CreatedAt           *time.Time `json:"created_at,omitempty"`
+	UpdatedAt           *time.Time `json:"updated_at,omitempty"`
+}
+
+// CompanySummaryResponse は企業一覧（軽量な表現）のレスポンス形式を表します
+type CompanySummaryResponse struct {
+	Companies []CompanySummary `json:"companies"`
+	Pagination
+}
+
+// Pagination は一覧のページング情報です。
+// ページ番号でのページングでは total・page を、カーソルでのページングでは次のページがある場合に next_cursor を返します。
+type Pagination struct {
+	Total      *int    `json:"total,omitempty"`
+	Page       int     `json:"page,omitempty"`
+	Limit      int     `json:"limit"`
+	NextCursor *string `json:"next_cursor,omitempty"`
 }
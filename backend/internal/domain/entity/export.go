@@ -0,0 +1,113 @@
+package entity
+
+import "time"
+
+// ExportFormatName・ExportFormatVersion はエクスポートの形式の識別子とバージョンです。
+// アーカイブ（JSON）とJSON Lines の先頭に含め、インポートで形式の判定に使用します。
+const (
+	ExportFormatName    = "ai-interview-practice/export"
+	ExportFormatVersion = 1
+)
+
+// ExportTable はエクスポートする表の名前です（データベースのテーブル名と同じ）
+type ExportTable string
+
+const (
+	ExportTableCompanies            ExportTable = "companies"
+	ExportTableCompanyCustomFields  ExportTable = "company_custom_fields"
+	ExportTableJobPostings          ExportTable = "job_postings"
+	ExportTableJobCustomFields      ExportTable = "job_custom_fields"
+	ExportTableInterviewSessions    ExportTable = "interview_sessions"
+	ExportTableInterviewQuestions   ExportTable = "interview_questions"
+	ExportTableInterviewAnswers     ExportTable = "interview_answers"
+	ExportTableInterviewEvaluations ExportTable = "interview_evaluations"
+	ExportTableAnswerEvaluations    ExportTable = "answer_evaluations"
+)
+
+// 以下はエクスポートする各表の行です。関連は持たず、親の ID で参照します。
+
+type ExportCompany struct {
+	ID                  int       `json:"id"`
+	Name                string    `json:"name"`
+	BusinessDescription *string   `json:"business_description"`
+	CreatedAt           time.Time `json:"created_at"`
+	UpdatedAt           time.Time `json:"updated_at"`
+}
+
+type ExportCompanyCustomField struct {
+	ID        int       `json:"id"`
+	CompanyID int       `json:"company_id"`
+	FieldName string    `json:"field_name"`
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type ExportJobPosting struct {
+	ID          int       `json:"id"`
+	CompanyID   int       `json:"company_id"`
+	Title       string    `json:"title"`
+	Description *string   `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type ExportJobCustomField struct {
+	ID           int       `json:"id"`
+	JobPostingID int       `json:"job_posting_id" gorm:"column:job_id"`
+	FieldName    string    `json:"field_name"`
+	Content      string    `json:"content"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+type ExportInterviewSession struct {
+	ID                      int                    `json:"id"`
+	CompanyID               *int                   `json:"company_id"`
+	JobPostingID            *int                   `json:"job_posting_id"`
+	InterviewPhase          *string                `json:"interview_phase"`
+	InterviewerRole         *string                `json:"interviewer_role"`
+	QuestionCount           int                    `json:"question_count"`
+	IncludeSelfIntroduction bool                   `json:"include_self_introduction"`
+	IncludeIceBreak         bool                   `json:"include_ice_break"`
+	Status                  InterviewSessionStatus `json:"status"`
+	StartedAt               time.Time              `json:"started_at"`
+	EndedAt                 *time.Time             `json:"ended_at"`
+}
+
+type ExportInterviewQuestion struct {
+	ID            int       `json:"id"`
+	SessionID     int       `json:"session_id"`
+	Content       string    `json:"content"`
+	Sequence      int       `json:"sequence"`
+	PromptVersion *string   `json:"prompt_version"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type ExportInterviewAnswer struct {
+	ID         int       `json:"id"`
+	QuestionID int       `json:"question_id"`
+	Content    string    `json:"content"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type ExportInterviewEvaluation struct {
+	ID               int            `json:"id"`
+	SessionID        int            `json:"session_id"`
+	TotalRank        EvaluationRank `json:"total_rank"`
+	TotalScore       int            `json:"total_score"`
+	OverallComment   string         `json:"overall_comment"`
+	EvaluationScores `gorm:"embedded"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+type ExportAnswerEvaluation struct {
+	ID               int `json:"id"`
+	SessionID        int `json:"session_id"`
+	QuestionID       int `json:"question_id"`
+	EvaluationScores `gorm:"embedded"`
+	QuestionComment  string     `json:"question_comment"`
+	Strengths        StringList `json:"strengths" gorm:"type:json"`
+	Improvements     StringList `json:"improvements" gorm:"type:json"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
@@ -0,0 +1,83 @@
+package entity
+
+import "github.com/takanoakira/ai-interview-practice/backend/internal/apperror"
+
+// ImportCompany は一括インポートで作成する企業です。
+// Row は入力での位置（CSV は行番号、JSON は配列の1始まりの番号）で、エラーの報告に使用します。
+// SourceID はエクスポートのアーカイブでの ID です（アーカイブ以外は 0）。
+type ImportCompany struct {
+	Row         int
+	SourceID    int
+	Company     Company
+	JobPostings []ImportJobPosting
+}
+
+// ImportJobPosting は一括インポートで企業と共に作成する求人情報です。
+// Path はエラーの報告で項目名の前に付ける位置（JSON の job_postings[0] など）です。
+// SourceID はエクスポートのアーカイブでの ID です（アーカイブ以外は 0）。
+type ImportJobPosting struct {
+	Row        int
+	Path       string
+	SourceID   int
+	JobPosting JobPosting
+}
+
+// ImportArchive はエクスポートのアーカイブから作成する企業・求人情報と面接の記録です
+type ImportArchive struct {
+	Companies []ImportCompany
+	Sessions  []ImportSession
+}
+
+// ImportSession はエクスポートのアーカイブから作成する面接セッションと、その質問・回答・評価です。
+// Row は interview_sessions の1始まりの番号です。
+// ID・参照はアーカイブでの値で、作成時に新しい ID に付け替えます
+// （Session の CompanyID・JobPostingID は ImportCompany・ImportJobPosting の SourceID、評価の QuestionID は Session.Questions の ID）。
+type ImportSession struct {
+	Row        int
+	Session    InterviewSession
+	Evaluation *InterviewEvaluation
+}
+
+// ImportResult は一括インポートの結果です。
+// 確認のみ（DryRun）の場合、件数は作成される予定の件数です。Errors がある場合は何も作成されません。
+type ImportResult struct {
+	DryRun      bool `json:"dry_run"`
+	Companies   int  `json:"companies"`
+	JobPostings int  `json:"job_postings"`
+	// InterviewSessions はエクスポートのアーカイブから作成した面接セッションの件数です
+	InterviewSessions int               `json:"interview_sessions"`
+	Errors            []apperror.Detail `json:"errors"`
+}
+
+// ImportLinkedJobPosting は企業名で既存の企業に紐づけて作成する求人情報です（schema.org の JobPosting の取り込みなど）。
+// JobPosting.CompanyID が指定されている場合はその企業に作成し、指定されていない場合は Company.Name で企業を探します。
+type ImportLinkedJobPosting struct {
+	Row int
+	// Company は企業名が一致する企業がない場合に作成する企業です
+	Company    Company
+	JobPosting JobPosting
+	// Ignored は取り込まなかった元データのプロパティ名です
+	Ignored []string
+}
+
+// LinkedJobPostingResult は ImportLinkedJobPosting ごとの取り込み結果です。
+// 確認のみの場合、作成する企業・求人情報の ID は 0 です。
+type LinkedJobPostingResult struct {
+	Row          int    `json:"row"`
+	Title        string `json:"title"`
+	JobPostingID int    `json:"job_posting_id"`
+	CompanyID    int    `json:"company_id"`
+	CompanyName  string `json:"company_name"`
+	// CompanyCreated は一致する企業がなく、企業を作成した場合に true です
+	CompanyCreated    bool     `json:"company_created"`
+	IgnoredProperties []string `json:"ignored_properties"`
+}
+
+// LinkedImportResult は企業名で紐づける求人情報の取り込み結果です。Errors がある場合は何も作成されません。
+type LinkedImportResult struct {
+	DryRun      bool                     `json:"dry_run"`
+	Companies   int                      `json:"companies"`
+	JobPostings int                      `json:"job_postings"`
+	Items       []LinkedJobPostingResult `json:"items"`
+	Errors      []apperror.Detail        `json:"errors"`
+}
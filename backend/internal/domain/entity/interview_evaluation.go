@@ -0,0 +1,90 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// EvaluationRank は総合ランクを表します
+type EvaluationRank string
+
+const (
+	EvaluationRankA EvaluationRank = "A"
+	EvaluationRankB EvaluationRank = "B"
+	EvaluationRankC EvaluationRank = "C"
+	EvaluationRankD EvaluationRank = "D"
+	EvaluationRankE EvaluationRank = "E"
+)
+
+// EvaluationScores は6つの評価軸のスコア（0-100）を表します
+type EvaluationScores struct {
+	LogicalScore        int `json:"logical_score" gorm:"column:logical_score"`
+	CommunicationScore  int `json:"communication_score" gorm:"column:communication_score"`
+	TechnicalScore      int `json:"technical_score" gorm:"column:technical_score"`
+	ProblemSolvingScore int `json:"problem_solving_score" gorm:"column:problem_solving_score"`
+	MotivationScore     int `json:"motivation_score" gorm:"column:motivation_score"`
+	CultureFitScore     int `json:"culture_fit_score" gorm:"column:culture_fit_score"`
+}
+
+// StringList は JSON 配列としてカラムに保存される文字列のリストです
+type StringList []string
+
+// Value は StringList を JSON に変換します
+func (l StringList) Value() (driver.Value, error) {
+	if l == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal([]string(l))
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan は JSON カラムの値を StringList に変換します
+func (l *StringList) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*l = StringList{}
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("unsupported type for StringList: %T", value)
+	}
+	return json.Unmarshal(data, (*[]string)(l))
+}
+
+// AnswerEvaluation は質問ごとの回答評価を表すエンティティです
+type AnswerEvaluation struct {
+	ID               int `json:"id" gorm:"primaryKey;autoIncrement"`
+	SessionID        int `json:"session_id"`
+	QuestionID       int `json:"question_id"`
+	EvaluationScores `gorm:"embedded"`
+	QuestionComment  string     `json:"question_comment"`
+	Strengths        StringList `json:"strengths" gorm:"type:json"`
+	Improvements     StringList `json:"improvements" gorm:"type:json"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+// InterviewEvaluation は面接セッション全体の評価を表すエンティティです
+type InterviewEvaluation struct {
+	ID                int                `json:"id" gorm:"primaryKey;autoIncrement"`
+	SessionID         int                `json:"session_id"`
+	TotalRank         EvaluationRank     `json:"total_rank"`
+	TotalScore        int                `json:"total_score"`
+	OverallComment    string             `json:"overall_comment"`
+	Scores            EvaluationScores   `json:"scores" gorm:"embedded"`
+	AnswerEvaluations []AnswerEvaluation `json:"answer_evaluations" gorm:"foreignKey:SessionID;references:SessionID"`
+	CreatedAt         time.Time          `json:"created_at"`
+}
+
+// InterviewEvaluationResponse は評価APIのレスポンス形式を表します
+type InterviewEvaluationResponse struct {
+	Evaluation *InterviewEvaluation `json:"evaluation"`
+}
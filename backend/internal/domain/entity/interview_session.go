@@ -0,0 +1,79 @@
+package entity
+
+import "time"
+
+// InterviewSessionStatus は面接セッションの実施状態を表します
+type InterviewSessionStatus string
+
+const (
+	InterviewSessionStatusCreated          InterviewSessionStatus = "CREATED"
+	InterviewSessionStatusGreeting         InterviewSessionStatus = "GREETING"
+	InterviewSessionStatusSelfIntroduction InterviewSessionStatus = "SELF_INTRODUCTION"
+	InterviewSessionStatusIceBreak         InterviewSessionStatus = "ICE_BREAK"
+	InterviewSessionStatusMain             InterviewSessionStatus = "MAIN"
+	InterviewSessionStatusPaused           InterviewSessionStatus = "PAUSED"
+	InterviewSessionStatusCompleted        InterviewSessionStatus = "COMPLETED"
+	InterviewSessionStatusTerminated       InterviewSessionStatus = "TERMINATED"
+	InterviewSessionStatusClosing          InterviewSessionStatus = "CLOSING"
+)
+
+// InterviewSession は面接セッションを表すエンティティです
+type InterviewSession struct {
+	ID                      int                    `json:"id" gorm:"primaryKey;autoIncrement"`
+	OwnerUserID             int                    `json:"owner_user_id"`
+	OrganizationID          *int                   `json:"organization_id"`
+	CompanyID               *int                   `json:"company_id"`
+	JobPostingID            *int                   `json:"job_posting_id"`
+	InterviewPhase          *string                `json:"interview_phase"`
+	InterviewerRole         *string                `json:"interviewer_role"`
+	QuestionCount           int                    `json:"question_count"`
+	IncludeSelfIntroduction bool                   `json:"include_self_introduction"`
+	IncludeIceBreak         bool                   `json:"include_ice_break"`
+	Status                  InterviewSessionStatus `json:"status"`
+	Questions               []InterviewQuestion    `json:"questions,omitempty" gorm:"foreignKey:SessionID"`
+	StartedAt               time.Time              `json:"started_at"`
+	EndedAt                 *time.Time             `json:"ended_at"`
+}
+
+// InterviewQuestion は面接官の質問を表すエンティティです
+type InterviewQuestion struct {
+	ID        int              `json:"id" gorm:"primaryKey;autoIncrement"`
+	SessionID int              `json:"session_id"`
+	Content   string           `json:"content"`
+	Sequence  int              `json:"sequence"`
+	Answer    *InterviewAnswer `json:"answer,omitempty" gorm:"foreignKey:QuestionID"`
+	// PromptVersion は質問の生成に使用したプロンプトのバージョンです（固定文言の場合は nil）
+	PromptVersion *string   `json:"prompt_version,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// InterviewAnswer は質問に対する応募者の回答を表すエンティティです
+type InterviewAnswer struct {
+	ID         int       `json:"id" gorm:"primaryKey;autoIncrement"`
+	QuestionID int       `json:"question_id"`
+	Content    string    `json:"content"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// InterviewQuestionResponse はクライアントへ返す質問の形式を表します
+type InterviewQuestionResponse struct {
+	ID       int    `json:"id"`
+	Content  string `json:"content"`
+	Sequence int    `json:"sequence"`
+}
+
+// GreetingResponse は挨拶取得APIのレスポンス形式を表します
+type GreetingResponse struct {
+	Question     InterviewQuestionResponse `json:"question"`
+	NextStatus   InterviewSessionStatus    `json:"next_status"`
+	AudioEnabled bool                      `json:"audio_enabled"`
+}
+
+// InterviewProgressResponse は回答送信後のレスポンス形式を表します
+type InterviewProgressResponse struct {
+	Status             InterviewSessionStatus     `json:"status"`
+	NextQuestion       *InterviewQuestionResponse `json:"next_question"`
+	AudioEnabled       bool                       `json:"audio_enabled"`
+	RemainingQuestions int                        `json:"remaining_questions"`
+	ShouldEndSession   bool                       `json:"should_end_session"`
+}
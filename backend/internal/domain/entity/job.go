@@ -0,0 +1,41 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// JobStatus は非同期ジョブの状態を表します
+type JobStatus string
+
+const (
+	JobStatusPending   JobStatus = "pending"
+	JobStatusRunning   JobStatus = "running"
+	JobStatusSucceeded JobStatus = "succeeded"
+	JobStatusFailed    JobStatus = "failed"
+)
+
+// Job はワーカーで非同期に処理されるジョブを表すエンティティです
+type Job struct {
+	ID             int             `json:"id" gorm:"primaryKey;autoIncrement"`
+	OwnerUserID    int             `json:"-"`
+	OrganizationID *int            `json:"-"`
+	Type           string          `json:"type"`
+	Payload        json.RawMessage `json:"-" gorm:"type:json"`
+	Status         JobStatus       `json:"status"`
+	Attempts       int             `json:"attempts"`
+	MaxAttempts    int             `json:"max_attempts"`
+	// TimeoutSeconds は1回の実行に許容する時間です
+	TimeoutSeconds int `json:"-"`
+	// LockedBy は実行中のワーカーの識別子です
+	LockedBy *string `json:"-"`
+	// LeaseExpiresAt は実行中のワーカーのリース期限です。期限を過ぎた running のジョブは再投入されます。
+	LeaseExpiresAt *time.Time      `json:"-"`
+	LastError      *string         `json:"last_error"`
+	Result         json.RawMessage `json:"result,omitempty" gorm:"type:json"`
+	RunAt          time.Time       `json:"run_at"`
+	StartedAt      *time.Time      `json:"started_at"`
+	FinishedAt     *time.Time      `json:"finished_at"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
@@ -1,6 +1,10 @@
 package entity
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 // JobPosting は求人情報の作成リクエストを表すエンティティです
 type JobPosting struct {
@@ -8,9 +12,12 @@ type JobPosting struct {
 	CompanyID    int              `json:"company_id"`
 	Title        string           `json:"title"`
 	Description  *string          `json:"description,omitempty"`
+	Version      int              `json:"version" gorm:"not null;default:1"`
 	CustomFields []JobCustomField `json:"custom_fields" gorm:"foreignKey:JobID"`
 	CreatedAt    time.Time        `json:"created_at"`
 	UpdatedAt    time.Time        `json:"updated_at"`
+	// DeletedAt はゴミ箱に移動した日時です。削除済みの求人情報は通常の検索の対象になりません。
+	DeletedAt gorm.DeletedAt `json:"deleted_at" gorm:"index"`
 }
 
 // JobCustomField は求人のカスタムフィールドを表すエンティティです
@@ -22,3 +29,22 @@ type JobCustomField struct {
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
+
+// JobPostingResponse は求人情報一覧のレスポンス形式を表します
+type JobPostingResponse struct {
+	JobPostings []JobPosting `json:"job_postings"`
+	Total       int          `json:"total"`
+	Page        int          `json:"page"`
+	Limit       int          `json:"limit"`
+}
+
+// JobPostingMove は求人情報の企業間の移動履歴を表すエンティティです
+type JobPostingMove struct {
+	ID            int       `json:"id" gorm:"primaryKey;autoIncrement"`
+	JobPostingID  int       `json:"job_posting_id"`
+	FromCompanyID int       `json:"from_company_id"`
+	ToCompanyID   int       `json:"to_company_id"`
+	MovedByUserID *int      `json:"moved_by_user_id"`
+	Reason        *string   `json:"reason"`
+	CreatedAt     time.Time `json:"created_at"`
+}
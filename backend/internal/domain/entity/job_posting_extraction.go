@@ -0,0 +1,50 @@
+package entity
+
+// SalaryUnit は給与の期間の単位です
+type SalaryUnit string
+
+const (
+	SalaryUnitYear  SalaryUnit = "year"
+	SalaryUnitMonth SalaryUnit = "month"
+	SalaryUnitWeek  SalaryUnit = "week"
+	SalaryUnitDay   SalaryUnit = "day"
+	SalaryUnitHour  SalaryUnit = "hour"
+)
+
+// 求人票から取り込んだ項目を保存する、求人の追加情報の項目名です
+const (
+	JobFieldEmploymentType = "雇用形態"
+	JobFieldSalary         = "給与"
+	JobFieldLocation       = "勤務地"
+	JobFieldRequiredSkills = "必須スキル"
+	JobFieldBenefits       = "福利厚生"
+)
+
+// SalaryRange は求人の給与の範囲です。Text は抽出元の表記で、Min・Max は読み取れた場合のみ設定します。
+type SalaryRange struct {
+	Min      *int       `json:"min,omitempty"`
+	Max      *int       `json:"max,omitempty"`
+	Currency string     `json:"currency,omitempty"`
+	Unit     SalaryUnit `json:"unit,omitempty"`
+	Text     string     `json:"text"`
+}
+
+// ExtractedJobPosting は求人票のテキストから抽出した項目です。抽出できなかった項目はゼロ値です。
+type ExtractedJobPosting struct {
+	Title          string       `json:"title,omitempty"`
+	Description    string       `json:"description,omitempty"`
+	EmploymentType string       `json:"employment_type,omitempty"`
+	Salary         *SalaryRange `json:"salary,omitempty"`
+	Location       string       `json:"location,omitempty"`
+	RequiredSkills []string     `json:"required_skills,omitempty"`
+	Benefits       []string     `json:"benefits,omitempty"`
+}
+
+// JobPostingExtraction は求人票の解析結果です
+type JobPostingExtraction struct {
+	Extracted ExtractedJobPosting `json:"extracted"`
+	// LLMAssisted は LLM の抽出結果で補った項目がある場合に true です
+	LLMAssisted bool `json:"llm_assisted"`
+	// Warnings は抽出できなかった項目・LLM を利用できなかった理由などの注意事項です
+	Warnings []string `json:"warnings"`
+}
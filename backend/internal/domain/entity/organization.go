@@ -0,0 +1,57 @@
+package entity
+
+import "time"
+
+// MembershipRole は組織内でのユーザーの役割を表します
+type MembershipRole string
+
+const (
+	// MembershipRoleOwner は組織の作成者で、全ての操作が可能です
+	MembershipRoleOwner MembershipRole = "owner"
+	// MembershipRoleAdmin はメンバーの招待と企業・求人情報の管理が可能です
+	MembershipRoleAdmin MembershipRole = "admin"
+	// MembershipRoleCoach は企業・求人情報の管理が可能です
+	MembershipRoleCoach MembershipRole = "coach"
+	// MembershipRoleCandidate は企業・求人情報の閲覧と面接練習のみ可能です
+	MembershipRoleCandidate MembershipRole = "candidate"
+)
+
+// Organization はキャリアエージェントや企業などの組織ワークスペースを表すエンティティです
+type Organization struct {
+	ID        int       `json:"id" gorm:"primaryKey"`
+	Name      string    `json:"name" gorm:"not null;type:varchar(100)"`
+	CreatedAt time.Time `json:"created_at" gorm:"not null;default:CURRENT_TIMESTAMP"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"not null;default:CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP"`
+}
+
+// Membership はユーザーの組織への所属を表すエンティティです
+type Membership struct {
+	ID             int            `json:"id" gorm:"primaryKey"`
+	OrganizationID int            `json:"organization_id" gorm:"not null"`
+	UserID         int            `json:"user_id" gorm:"not null"`
+	Role           MembershipRole `json:"role" gorm:"not null;type:enum('owner','admin','coach','candidate')"`
+	Organization   *Organization  `json:"organization,omitempty" gorm:"foreignKey:OrganizationID"`
+	User           *User          `json:"user,omitempty" gorm:"foreignKey:UserID"`
+	CreatedAt      time.Time      `json:"created_at" gorm:"not null;default:CURRENT_TIMESTAMP"`
+	UpdatedAt      time.Time      `json:"updated_at" gorm:"not null;default:CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP"`
+}
+
+// OrganizationInvitation は組織への招待を表すエンティティです。招待トークンはハッシュ値のみを保存します。
+type OrganizationInvitation struct {
+	ID               int            `json:"id" gorm:"primaryKey"`
+	OrganizationID   int            `json:"organization_id" gorm:"not null"`
+	TokenHash        string         `json:"-" gorm:"not null;type:char(64);uniqueIndex"`
+	Email            *string        `json:"email" gorm:"type:varchar(255)"`
+	Role             MembershipRole `json:"role" gorm:"not null;type:enum('admin','coach','candidate')"`
+	InvitedByUserID  int            `json:"invited_by_user_id" gorm:"not null"`
+	ExpiresAt        time.Time      `json:"expires_at" gorm:"not null"`
+	AcceptedAt       *time.Time     `json:"accepted_at"`
+	AcceptedByUserID *int           `json:"accepted_by_user_id"`
+	CreatedAt        time.Time      `json:"created_at" gorm:"not null;default:CURRENT_TIMESTAMP"`
+}
+
+// InvitationResponse は招待の作成結果です。Token は作成時にのみ返されます。
+type InvitationResponse struct {
+	Invitation OrganizationInvitation `json:"invitation"`
+	Token      string                 `json:"token"`
+}
@@ -0,0 +1,21 @@
+package entity
+
+import "time"
+
+// TrashedCompany はゴミ箱にある企業です。PurgeAt を過ぎると完全に削除されます。
+type TrashedCompany struct {
+	Company
+	PurgeAt time.Time `json:"purge_at"`
+}
+
+// TrashedJobPosting はゴミ箱にある求人情報です。PurgeAt を過ぎると完全に削除されます。
+type TrashedJobPosting struct {
+	JobPosting
+	PurgeAt time.Time `json:"purge_at"`
+}
+
+// TrashResponse はゴミ箱の一覧のレスポンス形式を表します
+type TrashResponse struct {
+	Companies   []TrashedCompany    `json:"companies"`
+	JobPostings []TrashedJobPosting `json:"job_postings"`
+}
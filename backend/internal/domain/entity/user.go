@@ -0,0 +1,15 @@
+package entity
+
+import "time"
+
+// User は認証済みのユーザーを表すエンティティです
+type User struct {
+	ID int `json:"id" gorm:"primaryKey"`
+	// AuthSubject は認証プロバイダーが発行した JWT の sub クレームです
+	AuthSubject   string    `json:"-" gorm:"not null;type:varchar(255);uniqueIndex"`
+	Email         *string   `json:"email" gorm:"type:varchar(255)"`
+	EmailVerified bool      `json:"email_verified" gorm:"not null;default:false"`
+	Name          *string   `json:"name" gorm:"type:varchar(255)"`
+	CreatedAt     time.Time `json:"created_at" gorm:"not null;default:CURRENT_TIMESTAMP"`
+	UpdatedAt     time.Time `json:"updated_at" gorm:"not null;default:CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP"`
+}
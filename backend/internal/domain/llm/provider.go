@@ -0,0 +1,97 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/apperror"
+)
+
+// ErrUnavailable は LLM サービスが一時的に利用できない場合（接続エラー、429、5xx）に返されます
+var ErrUnavailable = apperror.UpstreamUnavailable("llm provider unavailable")
+
+// Role はメッセージの発話者を表します
+type Role string
+
+const (
+	RoleSystem    Role = "system"
+	RoleUser      Role = "user"
+	RoleAssistant Role = "assistant"
+)
+
+// Message はチャット補完に渡す1件のメッセージを表します
+type Message struct {
+	Role    Role   `json:"role"`
+	Content string `json:"content"`
+}
+
+// JSONSchema は出力を JSON スキーマで制約する場合の指定を表します
+type JSONSchema struct {
+	Name   string          `json:"name"`
+	Schema json.RawMessage `json:"schema"`
+	Strict bool            `json:"strict"`
+}
+
+// CompletionRequest はチャット補完のリクエストを表します
+type CompletionRequest struct {
+	Messages    []Message
+	JSONSchema  *JSONSchema
+	Temperature *float64
+	MaxTokens   int
+}
+
+// Usage はトークン使用量を表します
+type Usage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+// CompletionResponse はチャット補完の結果を表します
+type CompletionResponse struct {
+	Content string
+	Model   string
+	Usage   Usage
+}
+
+// Provider はチャット補完を提供する LLM の抽象です。
+// 実装は ctx のキャンセル・期限切れを尊重し、その場合は ctx.Err() をラップしたエラーを返します。
+type Provider interface {
+	Complete(ctx context.Context, req CompletionRequest) (*CompletionResponse, error)
+}
+
+// DeltaFunc はストリーミング中に生成されたテキストの差分を受け取ります。
+// エラーを返すと生成を中断します。
+type DeltaFunc func(delta string) error
+
+// StreamingProvider はテキストを逐次生成できる Provider です
+type StreamingProvider interface {
+	Provider
+	// Stream は生成されたテキストを onDelta に逐次渡し、完了後に全体の結果を返します
+	Stream(ctx context.Context, req CompletionRequest, onDelta DeltaFunc) (*CompletionResponse, error)
+}
+
+// Stream は p がストリーミングに対応していれば逐次生成し、対応していなければ補完結果全体を1つの差分として onDelta に渡します
+func Stream(ctx context.Context, p Provider, req CompletionRequest, onDelta DeltaFunc) (*CompletionResponse, error) {
+	if sp, ok := p.(StreamingProvider); ok {
+		return sp.Stream(ctx, req, onDelta)
+	}
+	resp, err := p.Complete(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := onDelta(resp.Content); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// SystemMessage はシステムメッセージを生成します
+func SystemMessage(content string) Message {
+	return Message{Role: RoleSystem, Content: content}
+}
+
+// UserMessage はユーザーメッセージを生成します
+func UserMessage(content string) Message {
+	return Message{Role: RoleUser, Content: content}
+}
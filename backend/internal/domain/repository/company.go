@@ -2,13 +2,92 @@ package repository
 
 import (
 	"context"
+	"time"
 
+	"github.com/takanoakira/ai-interview-practice/backend/internal/apperror"
 	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
 )
 
+// ErrDuplicateCompanyName は同じワークスペースに同名の企業が存在する場合に返されます
+var ErrDuplicateCompanyName = apperror.Duplicate("company with the same name already exists",
+	apperror.Detail{Field: "name", Message: "already exists"})
+
+// CompanySort は企業一覧の並び順の基準です
+type CompanySort string
+
+const (
+	CompanySortID              CompanySort = "id"
+	CompanySortName            CompanySort = "name"
+	CompanySortCreatedAt       CompanySort = "created_at"
+	CompanySortUpdatedAt       CompanySort = "updated_at"
+	CompanySortJobPostingCount CompanySort = "job_posting_count"
+)
+
+// CompanySummaryField は企業一覧の軽量な表現（CompanySummary）で取得できる項目です
+type CompanySummaryField string
+
+const (
+	CompanySummaryFieldName                CompanySummaryField = "name"
+	CompanySummaryFieldBusinessDescription CompanySummaryField = "business_description"
+	CompanySummaryFieldJobPostingCount     CompanySummaryField = "job_posting_count"
+	CompanySummaryFieldCustomFieldCount    CompanySummaryField = "custom_field_count"
+	CompanySummaryFieldVersion             CompanySummaryField = "version"
+	CompanySummaryFieldCreatedAt           CompanySummaryField = "created_at"
+	CompanySummaryFieldUpdatedAt           CompanySummaryField = "updated_at"
+)
+
+// ErrInvalidCursor はカーソルの形式が不正、または並び順がカーソルの発行時と異なる場合に返されます
+var ErrInvalidCursor = apperror.Validation("invalid cursor",
+	apperror.Detail{Field: "cursor", Message: "must be a next_cursor returned with the same sort"})
+
+// CompanyInclude は企業一覧で企業と共に取得する関連です
+type CompanyInclude struct {
+	CustomFields bool
+	// JobPostings は求人情報とその追加情報を取得します
+	JobPostings bool
+}
+
+// CompanyQuery は企業一覧の検索条件です。ゼロ値の条件は絞り込みに使用しません。
+type CompanyQuery struct {
+	Page  int
+	Limit int
+	// Cursor はキーセットページングのカーソル（前のページの next_cursor）です。
+	// nil の場合は Page でページングし、空文字列の場合は先頭のページを返します。カーソルでのページングでは総件数を数えません。
+	Cursor *string
+	// Include は GetCompanies で企業と共に取得する関連です
+	Include CompanyInclude
+	// Fields は GetCompanySummaries で取得する項目です（id は常に取得します）
+	Fields []CompanySummaryField
+	// Keywords は企業名・事業内容・追加情報の内容・求人タイトルの全文検索の語です。全ての語を含む企業に絞り込みます。
+	Keywords []string
+	// HasJobPostings は求人情報の有無で絞り込みます
+	HasJobPostings *bool
+	// CreatedAfter はこの日時以降に作成された企業に絞り込みます
+	CreatedAfter *time.Time
+	// Sort・Desc は並び順です。同じ値の企業は ID の昇順に並べます。
+	Sort CompanySort
+	Desc bool
+}
+
 type CompanyRepository interface {
-	GetCompanies(ctx context.Context, page, limit int) (*entity.CompanyResponse, error)
+	GetCompanies(ctx context.Context, query CompanyQuery) (*entity.CompanyResponse, error)
+	// GetCompanySummaries は企業一覧を軽量な表現で返します。関連する情報の件数は集計したサブクエリで求めます。
+	GetCompanySummaries(ctx context.Context, query CompanyQuery) (*entity.CompanySummaryResponse, error)
+	GetCompanyByID(ctx context.Context, id int) (*entity.Company, error)
+	// GetCompanyByName は呼び出し元のワークスペースで企業名が一致する企業を返します（関連する情報は含みません）
+	GetCompanyByName(ctx context.Context, name string) (*entity.Company, error)
+	// CompanyExists は企業が存在し、呼び出し元のワークスペースに属するかどうかを返します
+	CompanyExists(ctx context.Context, id int) (bool, error)
 	CreateCompany(ctx context.Context, company *entity.Company) error
+	// UpdateCompany は企業情報を更新し、バージョンを 1 増やします。
+	// company.Version が 0 でなく現在のバージョンと異なる場合は ErrVersionMismatch を返します。
 	UpdateCompany(ctx context.Context, company *entity.Company) error
-	DeleteCompany(ctx context.Context, id int) error
+	// DeleteCompany は企業情報を削除します。version が 0 でなく現在のバージョンと異なる場合は ErrVersionMismatch を返します。
+	DeleteCompany(ctx context.Context, id, version int) error
+	// ListDeletedCompanies はゴミ箱にある企業を削除日時の新しい順に返します
+	ListDeletedCompanies(ctx context.Context) ([]entity.Company, error)
+	// RestoreCompany はゴミ箱にある企業を元に戻します。同名の企業が存在する場合は ErrDuplicateCompanyName を返します。
+	RestoreCompany(ctx context.Context, id int) (*entity.Company, error)
+	// PurgeDeletedCompanies は before より前にゴミ箱に移動した企業を全てのワークスペースから完全に削除し、件数を返します
+	PurgeDeletedCompanies(ctx context.Context, before time.Time) (int64, error)
 }
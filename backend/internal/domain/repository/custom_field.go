@@ -0,0 +1,7 @@
+package repository
+
+import "github.com/takanoakira/ai-interview-practice/backend/internal/apperror"
+
+// ErrCustomFieldNotFound は更新で指定された追加情報の ID が更新対象の企業・求人情報に属さない場合に返されます
+var ErrCustomFieldNotFound = apperror.Unprocessable("custom field not found",
+	apperror.Detail{Field: "custom_fields", Message: "must refer to custom fields of the resource"})
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
+)
+
+// ExportRepository は呼び出し元のワークスペースのデータをエクスポート用に1行ずつ読み出します。
+// 各メソッドは ID の昇順に1行ずつ fn に渡し、全件をメモリに読み込みません。fn がエラーを返すと中断してそのエラーを返します。
+// ゴミ箱にある企業・求人情報とその子のレコードは含みません。面接の記録は呼び出し元が現在のワークスペースで作成したセッションのもののみです。
+// 表をまたいで一貫した内容にするには、Transactor.Snapshot の ctx で呼び出します。
+type ExportRepository interface {
+	EachCompany(ctx context.Context, fn func(*entity.ExportCompany) error) error
+	EachCompanyCustomField(ctx context.Context, fn func(*entity.ExportCompanyCustomField) error) error
+	EachJobPosting(ctx context.Context, fn func(*entity.ExportJobPosting) error) error
+	EachJobCustomField(ctx context.Context, fn func(*entity.ExportJobCustomField) error) error
+	EachInterviewSession(ctx context.Context, fn func(*entity.ExportInterviewSession) error) error
+	EachInterviewQuestion(ctx context.Context, fn func(*entity.ExportInterviewQuestion) error) error
+	EachInterviewAnswer(ctx context.Context, fn func(*entity.ExportInterviewAnswer) error) error
+	EachInterviewEvaluation(ctx context.Context, fn func(*entity.ExportInterviewEvaluation) error) error
+	EachAnswerEvaluation(ctx context.Context, fn func(*entity.ExportAnswerEvaluation) error) error
+}
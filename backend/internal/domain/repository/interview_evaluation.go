@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
+)
+
+type InterviewEvaluationRepository interface {
+	GetEvaluationBySession(ctx context.Context, sessionID int) (*entity.InterviewEvaluation, error)
+	// SaveEvaluation は回答評価・セッション評価の保存とセッションの CLOSING への遷移を1トランザクションで行います。
+	// セッションが COMPLETED でない場合は ErrSessionStatusConflict を返します。
+	SaveEvaluation(ctx context.Context, evaluation *entity.InterviewEvaluation) error
+}
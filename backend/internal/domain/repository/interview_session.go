@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/apperror"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
+)
+
+// ErrSessionStatusConflict は更新対象のセッションが想定したステータスでなかった場合に返されます
+var ErrSessionStatusConflict = apperror.Conflict("interview session status conflict")
+
+type InterviewSessionRepository interface {
+	CreateSession(ctx context.Context, session *entity.InterviewSession) error
+	GetSession(ctx context.Context, id int) (*entity.InterviewSession, error)
+	// AdvanceSession は回答の保存・次の質問の作成・ステータス更新を1トランザクションで行います。
+	// セッションのステータスが from と一致しない場合は ErrSessionStatusConflict を返します。
+	// answer と question は不要な場合 nil を指定できます。
+	AdvanceSession(ctx context.Context, session *entity.InterviewSession, from entity.InterviewSessionStatus, answer *entity.InterviewAnswer, question *entity.InterviewQuestion) error
+	// ImportSession はセッションを質問・回答と評価（nil の場合は作成しない）ごと、ステータス・日時を引き継いで作成します（エクスポートのアーカイブのインポートに使用）。
+	// ID は新しく採番し、評価の QuestionID は session.Questions の元の ID から新しい ID に付け替えます。
+	ImportSession(ctx context.Context, session *entity.InterviewSession, evaluation *entity.InterviewEvaluation) error
+}
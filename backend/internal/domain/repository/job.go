@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
+)
+
+type JobRepository interface {
+	CreateJob(ctx context.Context, job *entity.Job) error
+	GetJob(ctx context.Context, id int) (*entity.Job, error)
+	// ClaimNextJob は実行可能な pending のジョブを1件 running にし、workerID のリース（期限 leaseExpiresAt）を設定して返します。
+	// 実行可能なジョブがない場合は gorm.ErrRecordNotFound を返します。
+	ClaimNextJob(ctx context.Context, workerID string, now, leaseExpiresAt time.Time) (*entity.Job, error)
+	// ExtendLease は workerID が実行中のジョブのリース期限を延長します。
+	// リースを失った（期限切れで再投入された）場合は gorm.ErrRecordNotFound を返します。
+	ExtendLease(ctx context.Context, id int, workerID string, leaseExpiresAt time.Time) error
+	// MarkSucceeded・MarkFailed・Reschedule は workerID がリースを保持している場合のみ更新し、
+	// リースを失った場合は gorm.ErrRecordNotFound を返します
+	MarkSucceeded(ctx context.Context, id int, workerID string, result json.RawMessage) error
+	MarkFailed(ctx context.Context, id int, workerID string, lastError string) error
+	// Reschedule はジョブを pending に戻し、runAt 以降に再実行されるようにします
+	Reschedule(ctx context.Context, id int, workerID string, lastError string, runAt time.Time) error
+	// RequeueExpiredJobs はリース期限が now より前の running のジョブ（停止したワーカーが実行していたもの）を pending に戻します
+	RequeueExpiredJobs(ctx context.Context, now time.Time) (int64, error)
+}
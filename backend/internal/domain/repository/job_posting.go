@@ -2,12 +2,32 @@ package repository
 
 import (
 	"context"
+	"time"
 
+	"github.com/takanoakira/ai-interview-practice/backend/internal/apperror"
 	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
 )
 
+// ErrDuplicateJobPostingTitle は同じ企業に同じタイトルの求人が存在する場合に返されます
+var ErrDuplicateJobPostingTitle = apperror.Duplicate("job posting with the same title already exists",
+	apperror.Detail{Field: "title", Message: "already exists in the company"})
+
 type JobPostingRepository interface {
+	GetJobPosting(ctx context.Context, id int) (*entity.JobPosting, error)
+	ListJobPostingsByCompany(ctx context.Context, companyID, page, limit int) (*entity.JobPostingResponse, error)
 	CreateJobPosting(ctx context.Context, jobPosting *entity.JobPosting) (*entity.JobPosting, error)
+	// UpdateJobPosting は求人情報を更新し、バージョンを 1 増やします。所属する企業は変更しません（MoveJobPosting を使用します）。
+	// jobPosting.Version が 0 でなく現在のバージョンと異なる場合は ErrVersionMismatch を返します。
 	UpdateJobPosting(ctx context.Context, jobPosting *entity.JobPosting) (*entity.JobPosting, error)
-	DeleteJobPosting(ctx context.Context, id int) error
+	// MoveJobPosting は求人情報を別の企業に移動し、移動履歴を1トランザクションで記録します
+	MoveJobPosting(ctx context.Context, move *entity.JobPostingMove) (*entity.JobPosting, error)
+	ListJobPostingMoves(ctx context.Context, jobPostingID int) ([]entity.JobPostingMove, error)
+	// DeleteJobPosting は求人情報を削除します。version が 0 でなく現在のバージョンと異なる場合は ErrVersionMismatch を返します。
+	DeleteJobPosting(ctx context.Context, id, version int) error
+	// ListDeletedJobPostings はゴミ箱にある求人情報（削除されていない企業のもの）を削除日時の新しい順に返します
+	ListDeletedJobPostings(ctx context.Context) ([]entity.JobPosting, error)
+	// RestoreJobPosting はゴミ箱にある求人情報を元に戻します。同じタイトルの求人が存在する場合は ErrDuplicateJobPostingTitle を返します。
+	RestoreJobPosting(ctx context.Context, id int) (*entity.JobPosting, error)
+	// PurgeDeletedJobPostings は before より前にゴミ箱に移動した求人情報を全てのワークスペースから完全に削除し、件数を返します
+	PurgeDeletedJobPostings(ctx context.Context, before time.Time) (int64, error)
 }
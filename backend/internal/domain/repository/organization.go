@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/apperror"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
+)
+
+var (
+	// ErrInvitationUnavailable は招待が存在しない・期限切れ・使用済みの場合に返されます
+	ErrInvitationUnavailable = apperror.NotFound("invitation is invalid, expired or already accepted")
+	// ErrInvitationEmailMismatch は宛先を指定した招待を、確認済みのメールアドレスが一致しないユーザーが受諾しようとした場合に返されます
+	ErrInvitationEmailMismatch = apperror.Forbidden("invitation is addressed to a different email address")
+	// ErrAlreadyMember は招待されたユーザーが既に組織に所属している場合に返されます
+	ErrAlreadyMember = apperror.Conflict("user is already a member of the organization")
+)
+
+type OrganizationRepository interface {
+	// CreateOrganization は組織と作成者の owner としての所属を1トランザクションで作成します
+	CreateOrganization(ctx context.Context, organization *entity.Organization, ownerUserID int) (*entity.Membership, error)
+	// ListMembershipsByUser はユーザーの所属を組織の情報とともに返します
+	ListMembershipsByUser(ctx context.Context, userID int) ([]entity.Membership, error)
+	// GetMembership はユーザーの組織への所属を返します。所属していない場合は gorm.ErrRecordNotFound を返します。
+	GetMembership(ctx context.Context, organizationID, userID int) (*entity.Membership, error)
+	// ListMembers は組織のメンバーをユーザーの情報とともに返します
+	ListMembers(ctx context.Context, organizationID int) ([]entity.Membership, error)
+	CreateInvitation(ctx context.Context, invitation *entity.OrganizationInvitation) error
+	// AcceptInvitation は tokenHash に一致する招待を使用済みにし、ユーザーを招待のロールで組織に所属させます。
+	// 招待が利用できない場合は ErrInvitationUnavailable、既に所属している場合は ErrAlreadyMember を返します。
+	// 招待に宛先のメールアドレスがあり、ユーザーの確認済みのメールアドレスと一致しない場合は ErrInvitationEmailMismatch を返します。
+	AcceptInvitation(ctx context.Context, tokenHash string, userID int, now time.Time) (*entity.Membership, error)
+}
@@ -0,0 +1,13 @@
+package repository
+
+import "context"
+
+// Transactor は複数のリポジトリの操作を1つのトランザクションで実行します
+type Transactor interface {
+	// Transaction は fn をトランザクション内で実行します。fn がエラーを返した場合はロールバックします。
+	// fn に渡される ctx を使用したリポジトリの操作はトランザクションに参加します。
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+	// Snapshot は fn を読み取り専用の REPEATABLE READ トランザクション内で実行します。
+	// fn 内の読み取りは全て同じ時点のデータを参照します。既にトランザクション内の場合はそのトランザクションで実行します。
+	Snapshot(ctx context.Context, fn func(ctx context.Context) error) error
+}
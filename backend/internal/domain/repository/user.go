@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
+)
+
+type UserRepository interface {
+	GetUser(ctx context.Context, id int) (*entity.User, error)
+	// UpsertUserBySubject は auth_subject が一致するユーザーを返します。
+	// 存在しない場合は作成し、存在する場合はメールアドレス・名前を最新の値に更新します。
+	UpsertUserBySubject(ctx context.Context, user *entity.User) error
+}
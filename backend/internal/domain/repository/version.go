@@ -0,0 +1,6 @@
+package repository
+
+import "github.com/takanoakira/ai-interview-practice/backend/internal/apperror"
+
+// ErrVersionMismatch は更新・削除の対象が指定したバージョンから変更されている場合に返されます（楽観的排他制御）
+var ErrVersionMismatch = apperror.PreconditionFailed("resource has been modified by another request")
@@ -0,0 +1,62 @@
+package speech
+
+import (
+	"context"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/apperror"
+)
+
+// MaxAudioBytes は1回の音声認識で受け付ける音声データの上限です
+const MaxAudioBytes = 10 << 20
+
+var (
+	// ErrUnavailable は音声認識・音声合成サービスが利用できない場合に返されます
+	ErrUnavailable = apperror.UpstreamUnavailable("speech provider unavailable")
+	// ErrEmptyAudio は音声データが空の場合に返されます
+	ErrEmptyAudio = apperror.Validation("audio is empty")
+	// ErrAudioTooLarge は音声データが MaxAudioBytes を超える場合に返されます
+	ErrAudioTooLarge = apperror.PayloadTooLarge("audio is too large")
+	// ErrUnsupportedFormat は音声のコンテナ形式が WAV・WebM・OGG 以外の場合に返されます
+	ErrUnsupportedFormat = apperror.Validation("unsupported audio format")
+)
+
+// Audio は音声認識に渡す音声データです。音声データは処理後に破棄し、永続化しません。
+type Audio struct {
+	Data []byte
+	// MimeType は音声のコンテナ形式です（例: audio/wav, audio/webm）。音声認識の前にデータから判定した値で上書きされます。
+	MimeType string
+	// Filename はクライアントが指定したファイル名です。指定されていない場合は空になります。
+	Filename string
+	// Language は認識対象の言語コードです（例: ja）
+	Language string
+}
+
+// Transcript は音声認識の結果です
+type Transcript struct {
+	Text string `json:"text"`
+	// Confidence は認識結果の信頼度（0〜1）です
+	Confidence float64 `json:"confidence"`
+	Language   string  `json:"language,omitempty"`
+}
+
+// SynthesisRequest は音声合成のリクエストです
+type SynthesisRequest struct {
+	Text  string
+	Voice string
+}
+
+// SynthesizedAudio は音声合成の結果です
+type SynthesizedAudio struct {
+	Data     []byte
+	MimeType string
+}
+
+// SpeechToText は音声をテキストに変換する音声認識の抽象です
+type SpeechToText interface {
+	Transcribe(ctx context.Context, audio Audio) (*Transcript, error)
+}
+
+// TextToSpeech はテキストを音声に変換する音声合成の抽象です
+type TextToSpeech interface {
+	Synthesize(ctx context.Context, req SynthesisRequest) (*SynthesizedAudio, error)
+}
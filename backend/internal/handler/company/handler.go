@@ -6,15 +6,20 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/takanoakira/ai-interview-practice/backend/internal/apperror"
 	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/handler/request"
 	"github.com/takanoakira/ai-interview-practice/backend/internal/usecase/company"
 )
 
 type Handler interface {
 	GetCompanies(c *gin.Context)
+	GetCompany(c *gin.Context)
 	CreateCompany(c *gin.Context)
 	UpdateCompany(c *gin.Context)
+	PatchCompany(c *gin.Context)
 	DeleteCompany(c *gin.Context)
+	RestoreCompany(c *gin.Context)
 }
 
 type handler struct {
@@ -25,92 +30,255 @@ func NewHandler(usecase company.UseCase) Handler {
 	return &handler{usecase: usecase}
 }
 
+// CreateCompanyRequest は企業情報の作成・更新リクエストです。
+// 追加情報は1件の企業につき20件までです。
+type CreateCompanyRequest struct {
+	Name                string                            `json:"name" binding:"required,max=100"`
+	BusinessDescription *string                           `json:"business_description,omitempty" binding:"omitempty,max=1000"`
+	CustomFields        []CreateCompanyCustomFieldRequest `json:"custom_fields,omitempty" binding:"omitempty,max=20,dive"`
+}
+
+// CreateCompanyCustomFieldRequest は企業の追加情報の作成リクエストです（規則はインポートと共通）
+type CreateCompanyCustomFieldRequest = request.CustomField
+
+// UpdateCompanyRequest は企業情報の更新（PUT）リクエストです。
+// 追加情報は送信した内容に置き換わります。既存の追加情報を残す場合は id を指定します（id のない項目は追加されます）。
+type UpdateCompanyRequest struct {
+	Name                string                            `json:"name" binding:"required,max=100"`
+	BusinessDescription *string                           `json:"business_description,omitempty" binding:"omitempty,max=1000"`
+	CustomFields        []UpdateCompanyCustomFieldRequest `json:"custom_fields,omitempty" binding:"omitempty,max=20,dive"`
+}
+
+type UpdateCompanyCustomFieldRequest struct {
+	ID        int    `json:"id,omitempty" binding:"omitempty,min=1"`
+	FieldName string `json:"field_name" binding:"required,max=50"`
+	Content   string `json:"content" binding:"required,max=500"`
+}
+
+// toEntity はリクエストを企業エンティティに変換します。ID・所有者・日時はサーバー側で設定します。
+func (r *CreateCompanyRequest) toEntity() *entity.Company {
+	company := &entity.Company{
+		Name:                r.Name,
+		BusinessDescription: r.BusinessDescription,
+	}
+	for _, field := range r.CustomFields {
+		company.CustomFields = append(company.CustomFields, entity.CompanyCustomField{
+			FieldName: field.FieldName,
+			Content:   field.Content,
+		})
+	}
+	return company
+}
+
+// toEntity はリクエストを ID の企業エンティティに変換します
+func (r *UpdateCompanyRequest) toEntity(id int) *entity.Company {
+	company := &entity.Company{
+		ID:                  id,
+		Name:                r.Name,
+		BusinessDescription: r.BusinessDescription,
+	}
+	for _, field := range r.CustomFields {
+		company.CustomFields = append(company.CustomFields, entity.CompanyCustomField{
+			ID:        field.ID,
+			FieldName: field.FieldName,
+			Content:   field.Content,
+		})
+	}
+	return company
+}
+
 func (h *handler) GetCompanies(c *gin.Context) {
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "6"))
+	query, err := parseCompanyQuery(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
 
-	if limit > 100 {
-		limit = 100
+	// fields を指定した場合は指定した項目のみの軽量な表現で返す
+	if query.Fields != nil {
+		summaries, err := h.usecase.GetCompanySummaries(c.Request.Context(), query)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		c.JSON(http.StatusOK, summaries)
+		return
 	}
 
-	companies, err := h.usecase.GetCompanies(c.Request.Context(), page, limit)
+	companies, err := h.usecase.GetCompanies(c.Request.Context(), query)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, companies)
 }
 
+func (h *handler) GetCompany(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.InvalidParameter("id"))
+		return
+	}
+
+	company, err := h.usecase.GetCompanyByID(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if request.NotModified(c, company.Version) {
+		return
+	}
+
+	request.SetETag(c, company.Version)
+	c.JSON(http.StatusOK, company)
+}
+
 func (h *handler) CreateCompany(c *gin.Context) {
-	var company entity.Company
-	if err := c.ShouldBindJSON(&company); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	var req CreateCompanyRequest
+	if err := request.BindStrictJSON(c, &req); err != nil {
+		c.Error(err)
 		return
 	}
 
-	if err := h.usecase.CreateCompany(c.Request.Context(), &company); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	company := req.toEntity()
+	if err := h.usecase.CreateCompany(c.Request.Context(), company); err != nil {
+		c.Error(err)
 		return
 	}
 
+	request.SetETag(c, company.Version)
 	c.JSON(http.StatusCreated, company)
 }
 
 func (h *handler) UpdateCompany(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid company ID"})
+		c.Error(apperror.InvalidParameter("id"))
+		return
+	}
+
+	var req UpdateCompanyRequest
+	if err := request.BindStrictJSON(c, &req); err != nil {
+		c.Error(err)
 		return
 	}
 
-	var company entity.Company
-	if err := c.ShouldBindJSON(&company); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	current, err := h.usecase.GetCompanyByID(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	version, err := request.IfMatch(c, current.Version)
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
-	company.ID = id
-	if err := h.usecase.UpdateCompany(c.Request.Context(), &company); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	company := req.toEntity(id)
+	company.Version = version
+	if err := h.usecase.UpdateCompany(c.Request.Context(), company); err != nil {
+		c.Error(err)
 		return
 	}
 
 	// 更新後の企業情報を取得
-	companies, err := h.usecase.GetCompanies(c.Request.Context(), 1, 100)
+	updatedCompany, err := h.usecase.GetCompanyByID(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
-	// 更新した企業を探す
-	var updatedCompany *entity.Company
-	for _, comp := range companies.Companies {
-		if comp.ID == id {
-			updatedCompany = &comp
-			break
-		}
+	request.SetETag(c, updatedCompany.Version)
+	c.JSON(http.StatusOK, updatedCompany)
+}
+
+// PatchCompany は merge patch（RFC 7396）で企業情報を部分的に更新します
+func (h *handler) PatchCompany(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.InvalidParameter("id"))
+		return
+	}
+
+	current, err := h.usecase.GetCompanyByID(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	version, err := request.IfMatch(c, current.Version)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var document CompanyPatchDocument
+	if err := request.BindMergePatch(c, newCompanyPatchDocument(current), &document); err != nil {
+		c.Error(err)
+		return
+	}
+
+	// patch は取得時点の内容に適用するため、If-Match がない場合も取得時点のバージョンで更新する
+	if version == 0 {
+		version = current.Version
+	}
+	company := document.toEntity(id)
+	company.Version = version
+	if err := h.usecase.UpdateCompany(c.Request.Context(), company); err != nil {
+		c.Error(err)
+		return
 	}
 
-	if updatedCompany == nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Updated company not found"})
+	updatedCompany, err := h.usecase.GetCompanyByID(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
+	request.SetETag(c, updatedCompany.Version)
 	c.JSON(http.StatusOK, updatedCompany)
 }
 
 func (h *handler) DeleteCompany(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid company ID"})
+		c.Error(apperror.InvalidParameter("id"))
+		return
+	}
+
+	current, err := h.usecase.GetCompanyByID(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	version, err := request.IfMatch(c, current.Version)
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
-	if err := h.usecase.DeleteCompany(c.Request.Context(), id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.usecase.DeleteCompany(c.Request.Context(), id, version); err != nil {
+		c.Error(err)
 		return
 	}
 
 	c.Status(http.StatusNoContent)
 }
+
+// RestoreCompany はゴミ箱にある企業を元に戻します
+func (h *handler) RestoreCompany(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.InvalidParameter("id"))
+		return
+	}
+
+	company, err := h.usecase.RestoreCompany(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	request.SetETag(c, company.Version)
+	c.JSON(http.StatusOK, company)
+}
@@ -0,0 +1,53 @@
+package company
+
+import (
+	"strconv"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/handler/request"
+)
+
+// CompanyPatchDocument は PATCH で merge patch を適用する企業情報の表現です。
+// 追加情報は ID をキーとするオブジェクトで表し、キーごとに更新（オブジェクト）・削除（null）できます。
+// 数値でないキー（"new" など）の項目は追加されます。
+//
+//	{"business_description": null, "custom_fields": {"12": {"content": "新しい内容"}, "13": null, "new": {"field_name": "社風", "content": "..."}}}
+type CompanyPatchDocument struct {
+	Name                string                                     `json:"name" binding:"required,max=100"`
+	BusinessDescription *string                                    `json:"business_description,omitempty" binding:"omitempty,max=1000"`
+	CustomFields        map[string]CreateCompanyCustomFieldRequest `json:"custom_fields,omitempty" binding:"omitempty,max=20,dive"`
+}
+
+// newCompanyPatchDocument は現在の企業情報から merge patch の適用対象を作成します
+func newCompanyPatchDocument(company *entity.Company) *CompanyPatchDocument {
+	document := &CompanyPatchDocument{
+		Name:                company.Name,
+		BusinessDescription: company.BusinessDescription,
+		CustomFields:        make(map[string]CreateCompanyCustomFieldRequest, len(company.CustomFields)),
+	}
+	for _, field := range company.CustomFields {
+		document.CustomFields[strconv.Itoa(field.ID)] = CreateCompanyCustomFieldRequest{
+			FieldName: field.FieldName,
+			Content:   field.Content,
+		}
+	}
+	return document
+}
+
+// toEntity は適用後のドキュメントを ID の企業エンティティに変換します
+func (d *CompanyPatchDocument) toEntity(id int) *entity.Company {
+	company := &entity.Company{
+		ID:                  id,
+		Name:                d.Name,
+		BusinessDescription: d.BusinessDescription,
+	}
+	for _, key := range request.SortedPatchKeys(d.CustomFields) {
+		field := d.CustomFields[key]
+		company.CustomFields = append(company.CustomFields, entity.CompanyCustomField{
+			ID:        request.PatchKeyID(key),
+			FieldName: field.FieldName,
+			Content:   field.Content,
+		})
+	}
+	return company
+}
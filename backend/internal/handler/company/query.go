@@ -0,0 +1,142 @@
+package company
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/apperror"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/repository"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/handler/request"
+)
+
+// maxQueryLength は企業一覧の q の最大文字数です
+const maxQueryLength = 100
+
+// companySorts は sort クエリパラメータに指定できる並び順です（先頭に - を付けると降順）
+var companySorts = map[string]repository.CompanySort{
+	"id":                repository.CompanySortID,
+	"name":              repository.CompanySortName,
+	"created_at":        repository.CompanySortCreatedAt,
+	"updated_at":        repository.CompanySortUpdatedAt,
+	"job_posting_count": repository.CompanySortJobPostingCount,
+}
+
+// companyIncludes は include クエリパラメータに指定できる関連です
+var companyIncludes = map[string]func(*repository.CompanyInclude){
+	"custom_fields": func(include *repository.CompanyInclude) { include.CustomFields = true },
+	"job_postings":  func(include *repository.CompanyInclude) { include.JobPostings = true },
+}
+
+// companySummaryFields は fields クエリパラメータに指定できる項目です
+var companySummaryFields = map[string]repository.CompanySummaryField{
+	"name":                 repository.CompanySummaryFieldName,
+	"business_description": repository.CompanySummaryFieldBusinessDescription,
+	"job_posting_count":    repository.CompanySummaryFieldJobPostingCount,
+	"custom_field_count":   repository.CompanySummaryFieldCustomFieldCount,
+	"version":              repository.CompanySummaryFieldVersion,
+	"created_at":           repository.CompanySummaryFieldCreatedAt,
+	"updated_at":           repository.CompanySummaryFieldUpdatedAt,
+}
+
+// parseCompanyQuery は企業一覧のクエリパラメータ（page・limit・cursor・include・fields・q・has_job_postings・created_after・sort）を解析します。
+// 不正なパラメータがある場合は、全てのパラメータの内容を details に含めた検証エラーを返します。
+func parseCompanyQuery(c *gin.Context) (repository.CompanyQuery, error) {
+	page, limit, err := request.Pagination(c, 6)
+	if err != nil {
+		return repository.CompanyQuery{}, err
+	}
+	// include を指定しない場合は従来通り全ての関連を取得する
+	query := repository.CompanyQuery{Page: page, Limit: limit, Include: repository.CompanyInclude{CustomFields: true, JobPostings: true}}
+
+	var details []apperror.Detail
+	if cursor, ok := c.GetQuery("cursor"); ok {
+		if _, ok := c.GetQuery("page"); ok {
+			details = append(details, apperror.Detail{Field: "cursor", Message: "cannot be combined with page"})
+		}
+		query.Cursor = &cursor
+	}
+	include, hasInclude := c.GetQuery("include")
+	if hasInclude {
+		query.Include = repository.CompanyInclude{}
+		for _, name := range splitList(include) {
+			set, ok := companyIncludes[name]
+			if !ok {
+				details = append(details, apperror.Detail{Field: "include", Message: "must be a comma-separated list of custom_fields job_postings"})
+				break
+			}
+			set(&query.Include)
+		}
+	}
+	if fields, ok := c.GetQuery("fields"); ok {
+		if hasInclude {
+			details = append(details, apperror.Detail{Field: "fields", Message: "cannot be combined with include"})
+		}
+		// fields を指定した場合は空でも軽量な表現（id のみ）で返す
+		query.Fields = []repository.CompanySummaryField{}
+		for _, name := range splitList(fields) {
+			field, ok := companySummaryFields[name]
+			if !ok {
+				details = append(details, apperror.Detail{Field: "fields", Message: "must be a comma-separated list of name business_description job_posting_count custom_field_count version created_at updated_at"})
+				break
+			}
+			query.Fields = append(query.Fields, field)
+		}
+	}
+	if q := c.Query("q"); q != "" {
+		if utf8.RuneCountInString(q) > maxQueryLength {
+			details = append(details, apperror.Detail{Field: "q", Message: "must be at most 100 characters"})
+		}
+		// 全角スペースも区切りとして扱う
+		query.Keywords = strings.Fields(q)
+	}
+	if v, ok := c.GetQuery("has_job_postings"); ok {
+		hasJobPostings, err := strconv.ParseBool(v)
+		if err != nil {
+			details = append(details, apperror.Detail{Field: "has_job_postings", Message: "must be true or false"})
+		}
+		query.HasJobPostings = &hasJobPostings
+	}
+	if v, ok := c.GetQuery("created_after"); ok {
+		createdAfter, err := parseTime(v)
+		if err != nil {
+			details = append(details, apperror.Detail{Field: "created_after", Message: "must be a date (YYYY-MM-DD) or an RFC 3339 date-time"})
+		}
+		query.CreatedAfter = &createdAfter
+	}
+	if v := c.Query("sort"); v != "" {
+		sort, ok := companySorts[strings.TrimPrefix(v, "-")]
+		if !ok {
+			details = append(details, apperror.Detail{Field: "sort", Message: "must be one of id name created_at updated_at job_posting_count (prefix - for descending order)"})
+		}
+		query.Sort = sort
+		query.Desc = strings.HasPrefix(v, "-")
+	}
+
+	if len(details) > 0 {
+		return repository.CompanyQuery{}, apperror.Validation("invalid query parameters", details...)
+	}
+	return query, nil
+}
+
+// splitList はカンマ区切りの値を分割します。空の要素は無視します。
+func splitList(v string) []string {
+	var items []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseTime は日付（サーバーのタイムゾーンの 0 時）または RFC 3339 の日時を解析します
+func parseTime(v string) (time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02", v, time.Local); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, v)
+}
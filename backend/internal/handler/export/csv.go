@@ -0,0 +1,119 @@
+package export
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/apperror"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
+)
+
+// csvWriter は表ごとに1つの CSV ファイル（表名.csv）を持つ zip を書き込みます
+type csvWriter struct {
+	zip        *zip.Writer
+	exportedAt time.Time
+	csv        *csv.Writer
+	// columns は現在の表の列の、行の構造体でのフィールドの位置です
+	columns [][]int
+}
+
+func newCSVWriter(w io.Writer, exportedAt time.Time) formatWriter {
+	return &csvWriter{zip: zip.NewWriter(w), exportedAt: exportedAt}
+}
+
+func (w *csvWriter) BeginTable(table entity.ExportTable, row interface{}) error {
+	file, err := w.zip.CreateHeader(&zip.FileHeader{
+		Name:     string(table) + ".csv",
+		Method:   zip.Deflate,
+		Modified: w.exportedAt,
+	})
+	if err != nil {
+		return err
+	}
+	// Excel で文字化けしないよう BOM を付ける
+	if _, err := io.WriteString(file, "\ufeff"); err != nil {
+		return err
+	}
+
+	var names []string
+	names, w.columns = csvColumns(reflect.TypeOf(row), nil)
+	w.csv = csv.NewWriter(file)
+	return w.csv.Write(names)
+}
+
+func (w *csvWriter) WriteRow(_ entity.ExportTable, row interface{}) error {
+	v := reflect.Indirect(reflect.ValueOf(row))
+	record := make([]string, len(w.columns))
+	for i, index := range w.columns {
+		value, err := csvValue(v.FieldByIndex(index))
+		if err != nil {
+			return err
+		}
+		record[i] = value
+	}
+	return w.csv.Write(record)
+}
+
+func (w *csvWriter) EndTable(entity.ExportTable) error {
+	w.csv.Flush()
+	return w.csv.Error()
+}
+
+func (w *csvWriter) Close() error {
+	return w.zip.Close()
+}
+
+// Fail は何も書き込みません（目次のない zip として不完全であることが分かります）
+func (w *csvWriter) Fail(*apperror.Error) {}
+
+// csvColumns は行の構造体の JSON のキーを列名として、列名とフィールドの位置を返します。
+// 埋め込みの構造体（評価のスコアなど）のフィールドは展開します。
+func csvColumns(t reflect.Type, prefix []int) ([]string, [][]int) {
+	var names []string
+	var columns [][]int
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		index := append(append([]int{}, prefix...), i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			embeddedNames, embeddedColumns := csvColumns(field.Type, index)
+			names = append(names, embeddedNames...)
+			columns = append(columns, embeddedColumns...)
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		names = append(names, name)
+		columns = append(columns, index)
+	}
+	return names, columns
+}
+
+// csvValue はフィールドの値を CSV のセルの文字列にします。nil は空、日時は RFC 3339、配列は JSON にします。
+func csvValue(v reflect.Value) (string, error) {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return "", nil
+		}
+		v = v.Elem()
+	}
+	switch value := v.Interface().(type) {
+	case time.Time:
+		return value.Format(time.RFC3339), nil
+	}
+	if v.Kind() == reflect.Slice {
+		if v.IsNil() {
+			return "", nil
+		}
+		data, err := json.Marshal(v.Interface())
+		return string(data), err
+	}
+	return fmt.Sprint(v.Interface()), nil
+}
@@ -0,0 +1,97 @@
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/apperror"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/usecase/export"
+)
+
+// bufferSize はレスポンスの書き込みのバッファのサイズです。
+// 最初のバッファが送信されるまでにエラーになった場合は、通常のエラーレスポンスを返せます。
+const bufferSize = 32 << 10
+
+type Handler interface {
+	Export(c *gin.Context)
+}
+
+type handler struct {
+	usecase export.UseCase
+}
+
+func NewHandler(usecase export.UseCase) Handler {
+	return &handler{usecase: usecase}
+}
+
+// formatWriter は形式ごとの Writer です
+type formatWriter interface {
+	export.Writer
+	// Close は形式の終端（アーカイブの閉じ括弧・zip の目次など）を書き込みます
+	Close() error
+	// Fail は送信を開始した後にエラーになった場合に呼び出されます。形式によってはエラーを書き込みます。
+	Fail(err *apperror.Error)
+}
+
+// format はエクスポートの形式です
+type format struct {
+	contentType string
+	extension   string
+	newWriter   func(w io.Writer, exportedAt time.Time) formatWriter
+}
+
+// formats は format クエリパラメータに指定できる形式です
+var formats = map[string]format{
+	"jsonl": {contentType: "application/x-ndjson", extension: "jsonl", newWriter: newJSONLinesWriter},
+	"csv":   {contentType: "application/zip", extension: "zip", newWriter: newCSVWriter},
+	"json":  {contentType: "application/json", extension: "json", newWriter: newArchiveWriter},
+}
+
+// Export はワークスペースの企業・求人情報・面接の記録をダウンロードします。
+// 行はデータベースから1行ずつ読み出して書き込み、全件をメモリに読み込みません。
+func (h *handler) Export(c *gin.Context) {
+	name := c.DefaultQuery("format", "jsonl")
+	f, ok := formats[name]
+	if !ok {
+		c.Error(apperror.Validation("invalid format parameter",
+			apperror.Detail{Field: "format", Message: "must be one of jsonl csv json"}))
+		return
+	}
+
+	exportedAt := time.Now()
+	buffered := bufio.NewWriterSize(c.Writer, bufferSize)
+	w := f.newWriter(buffered, exportedAt)
+	c.Header("Content-Type", f.contentType)
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="export-%s.%s"`, exportedAt.Format("20060102-150405"), f.extension))
+	c.Status(http.StatusOK)
+
+	err := h.usecase.Export(c.Request.Context(), w)
+	if err == nil {
+		err = w.Close()
+	}
+	if err == nil {
+		err = buffered.Flush()
+	}
+	if err == nil {
+		return
+	}
+
+	if !c.Writer.Written() {
+		// まだ何も送信していなければ、バッファを破棄して通常のエラーレスポンスを返す
+		c.Writer.Header().Del("Content-Type")
+		c.Writer.Header().Del("Content-Disposition")
+		c.Error(err)
+		return
+	}
+	// 送信を開始した後はステータスを変更できないため、途中で終了する（不完全なファイルになる）
+	appErr := apperror.From(err)
+	log.Printf("%s %s: export failed after streaming started: %v", c.Request.Method, c.Request.URL.Path, err)
+	w.Fail(appErr)
+	buffered.Flush()
+}
@@ -0,0 +1,116 @@
+package export
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/apperror"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
+)
+
+// exportHeader はエクスポートの形式・バージョン・日時です
+type exportHeader struct {
+	Format     string    `json:"format"`
+	Version    int       `json:"version"`
+	ExportedAt time.Time `json:"exported_at"`
+}
+
+func newExportHeader(exportedAt time.Time) exportHeader {
+	return exportHeader{Format: entity.ExportFormatName, Version: entity.ExportFormatVersion, ExportedAt: exportedAt}
+}
+
+// jsonLinesWriter は1行目に形式、以降に {"table": 表, "row": 行} を1行ずつ書き込みます
+type jsonLinesWriter struct {
+	encoder *json.Encoder
+	err     error
+}
+
+func newJSONLinesWriter(w io.Writer, exportedAt time.Time) formatWriter {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	return &jsonLinesWriter{encoder: encoder, err: encoder.Encode(newExportHeader(exportedAt))}
+}
+
+func (w *jsonLinesWriter) BeginTable(entity.ExportTable, interface{}) error {
+	return w.err
+}
+
+func (w *jsonLinesWriter) WriteRow(table entity.ExportTable, row interface{}) error {
+	return w.encoder.Encode(struct {
+		Table entity.ExportTable `json:"table"`
+		Row   interface{}        `json:"row"`
+	}{table, row})
+}
+
+func (w *jsonLinesWriter) EndTable(entity.ExportTable) error {
+	return nil
+}
+
+func (w *jsonLinesWriter) Close() error {
+	return nil
+}
+
+// Fail はエラーを {"error": ...} の行として書き込みます（途中で終了したことをクライアントが判別できるように）
+func (w *jsonLinesWriter) Fail(err *apperror.Error) {
+	w.encoder.Encode(struct {
+		Error *apperror.Error `json:"error"`
+	}{err})
+}
+
+// archiveWriter は形式と表ごとの行の配列を持つ1つの JSON オブジェクトを書き込みます。
+// 面接の記録を含む全ての表を POST /api/v1/imports でインポートできます（100MB まで）。
+type archiveWriter struct {
+	w       io.Writer
+	encoder *json.Encoder
+	// first は表の最初の行を書き込む前であることを表します
+	first bool
+	err   error
+}
+
+func newArchiveWriter(w io.Writer, exportedAt time.Time) formatWriter {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	header, err := json.Marshal(newExportHeader(exportedAt))
+	if err == nil {
+		// 閉じ括弧を除いて書き込み、表を続ける
+		_, err = w.Write(header[:len(header)-1])
+	}
+	return &archiveWriter{w: w, encoder: encoder, err: err}
+}
+
+func (w *archiveWriter) BeginTable(table entity.ExportTable, _ interface{}) error {
+	if w.err != nil {
+		return w.err
+	}
+	name, err := json.Marshal(table)
+	if err != nil {
+		return err
+	}
+	w.first = true
+	_, err = io.WriteString(w.w, ","+string(name)+":[\n")
+	return err
+}
+
+func (w *archiveWriter) WriteRow(_ entity.ExportTable, row interface{}) error {
+	if !w.first {
+		if _, err := io.WriteString(w.w, ","); err != nil {
+			return err
+		}
+	}
+	w.first = false
+	return w.encoder.Encode(row)
+}
+
+func (w *archiveWriter) EndTable(entity.ExportTable) error {
+	_, err := io.WriteString(w.w, "]")
+	return err
+}
+
+func (w *archiveWriter) Close() error {
+	_, err := io.WriteString(w.w, "}\n")
+	return err
+}
+
+// Fail は何も書き込みません（閉じていない JSON として不完全であることが分かります）
+func (w *archiveWriter) Fail(*apperror.Error) {}
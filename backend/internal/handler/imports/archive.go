@@ -0,0 +1,235 @@
+package imports
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gin-gonic/gin/binding"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/apperror"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/handler/request"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/usecase/imports"
+)
+
+// archive はエクスポートのアーカイブの表です
+type archive struct {
+	Format               string                             `json:"format"`
+	Version              int                                `json:"version"`
+	Companies            []entity.ExportCompany             `json:"companies"`
+	CompanyCustomFields  []entity.ExportCompanyCustomField  `json:"company_custom_fields"`
+	JobPostings          []entity.ExportJobPosting          `json:"job_postings"`
+	JobCustomFields      []entity.ExportJobCustomField      `json:"job_custom_fields"`
+	InterviewSessions    []entity.ExportInterviewSession    `json:"interview_sessions"`
+	InterviewQuestions   []entity.ExportInterviewQuestion   `json:"interview_questions"`
+	InterviewAnswers     []entity.ExportInterviewAnswer     `json:"interview_answers"`
+	InterviewEvaluations []entity.ExportInterviewEvaluation `json:"interview_evaluations"`
+	AnswerEvaluations    []entity.ExportAnswerEvaluation    `json:"answer_evaluations"`
+}
+
+// parseArchive はアーカイブの企業・求人情報と面接の記録を、ID の参照をたどって企業・面接セッションごとにまとめて読み込みます。
+// 企業の行は companies の1始まりの番号です。ID は引き継がず新しく採番し、面接の記録の参照は新しい ID に付け替えます。
+// 企業・求人情報の作成日時は引き継ぎませんが、面接の記録の日時は引き継ぎます。
+func parseArchive(data []byte) (*entity.ImportArchive, []apperror.Detail, error) {
+	var a archive
+	if err := json.Unmarshal(data, &a); err != nil {
+		return nil, nil, apperror.FromBinding(err)
+	}
+	if a.Format != entity.ExportFormatName {
+		return nil, nil, apperror.Validation("unknown archive format",
+			apperror.Detail{Field: "format", Message: "must be " + entity.ExportFormatName})
+	}
+	if a.Version < 1 || a.Version > entity.ExportFormatVersion {
+		return nil, nil, apperror.Validation("unsupported archive version",
+			apperror.Detail{Field: "version", Message: fmt.Sprintf("must be between 1 and %d", entity.ExportFormatVersion)})
+	}
+
+	companies, rowErrors, err := archiveCompanies(&a)
+	if err != nil {
+		return nil, nil, err
+	}
+	sessions, sessionErrors := archiveSessions(&a)
+	return &entity.ImportArchive{Companies: companies, Sessions: sessions}, append(rowErrors, sessionErrors...), nil
+}
+
+// archiveCompanies はアーカイブの企業・求人情報と追加情報を企業ごとにまとめます
+func archiveCompanies(a *archive) ([]entity.ImportCompany, []apperror.Detail, error) {
+
+	customFields := map[int][]request.CustomField{}
+	for _, field := range a.CompanyCustomFields {
+		customFields[field.CompanyID] = append(customFields[field.CompanyID], request.CustomField{FieldName: field.FieldName, Content: field.Content})
+	}
+	jobCustomFields := map[int][]request.CustomField{}
+	for _, field := range a.JobCustomFields {
+		jobCustomFields[field.JobPostingID] = append(jobCustomFields[field.JobPostingID], request.CustomField{FieldName: field.FieldName, Content: field.Content})
+	}
+	jobPostings := map[int][]ImportJobPostingRequest{}
+	jobPostingIDs := map[int][]int{}
+	for _, job := range a.JobPostings {
+		jobPostings[job.CompanyID] = append(jobPostings[job.CompanyID], ImportJobPostingRequest{
+			Title:        job.Title,
+			Description:  job.Description,
+			CustomFields: jobCustomFields[job.ID],
+		})
+		jobPostingIDs[job.CompanyID] = append(jobPostingIDs[job.CompanyID], job.ID)
+	}
+
+	var companies []entity.ImportCompany
+	var rowErrors []apperror.Detail
+	for i, company := range a.Companies {
+		row := i + 1
+		req := ImportCompanyRequest{
+			Name:                company.Name,
+			BusinessDescription: company.BusinessDescription,
+			CustomFields:        customFields[company.ID],
+			JobPostings:         jobPostings[company.ID],
+		}
+		if err := binding.Validator.ValidateStruct(&req); err != nil {
+			details, err := imports.RowErrors(row, "", err)
+			if err != nil {
+				return nil, nil, err
+			}
+			rowErrors = append(rowErrors, details...)
+			continue
+		}
+		item := req.toEntity(row)
+		item.SourceID = company.ID
+		for i, id := range jobPostingIDs[company.ID] {
+			item.JobPostings[i].SourceID = id
+		}
+		companies = append(companies, item)
+	}
+	return companies, rowErrors, nil
+}
+
+// archiveSessions はアーカイブの面接の記録を面接セッションごとにまとめます。
+// 参照先のない行・不正なステータスなどは interview_questions[行].session_id の形式のエラーとして返します（行は各表の1始まりの番号）。
+func archiveSessions(a *archive) ([]entity.ImportSession, []apperror.Detail) {
+	var details []apperror.Detail
+	invalid := func(table entity.ExportTable, index int, field, message string) {
+		details = append(details, apperror.Detail{Field: fmt.Sprintf("%s[%d].%s", table, index+1, field), Message: message})
+	}
+
+	sessions := make([]entity.ImportSession, len(a.InterviewSessions))
+	sessionIndex := map[int]int{}
+	for i, row := range a.InterviewSessions {
+		if !validSessionStatuses[row.Status] {
+			invalid(entity.ExportTableInterviewSessions, i, "status", "is invalid")
+		}
+		sessionIndex[row.ID] = i
+		sessions[i] = entity.ImportSession{
+			Row: i + 1,
+			Session: entity.InterviewSession{
+				CompanyID:               row.CompanyID,
+				JobPostingID:            row.JobPostingID,
+				InterviewPhase:          row.InterviewPhase,
+				InterviewerRole:         row.InterviewerRole,
+				QuestionCount:           row.QuestionCount,
+				IncludeSelfIntroduction: row.IncludeSelfIntroduction,
+				IncludeIceBreak:         row.IncludeIceBreak,
+				Status:                  row.Status,
+				StartedAt:               row.StartedAt,
+				EndedAt:                 row.EndedAt,
+			},
+		}
+	}
+
+	// 質問の ID から、セッションの番号と Questions での位置への対応
+	type questionRef struct{ session, index int }
+	questions := map[int]questionRef{}
+	for i, row := range a.InterviewQuestions {
+		s, ok := sessionIndex[row.SessionID]
+		if !ok {
+			invalid(entity.ExportTableInterviewQuestions, i, "session_id", "does not match any interview session")
+			continue
+		}
+		questions[row.ID] = questionRef{session: s, index: len(sessions[s].Session.Questions)}
+		sessions[s].Session.Questions = append(sessions[s].Session.Questions, entity.InterviewQuestion{
+			ID:            row.ID,
+			Content:       row.Content,
+			Sequence:      row.Sequence,
+			PromptVersion: row.PromptVersion,
+			CreatedAt:     row.CreatedAt,
+		})
+	}
+
+	for i, row := range a.InterviewAnswers {
+		ref, ok := questions[row.QuestionID]
+		if !ok {
+			invalid(entity.ExportTableInterviewAnswers, i, "question_id", "does not match any interview question")
+			continue
+		}
+		question := &sessions[ref.session].Session.Questions[ref.index]
+		if question.Answer != nil {
+			invalid(entity.ExportTableInterviewAnswers, i, "question_id", "duplicates another answer to the question")
+			continue
+		}
+		question.Answer = &entity.InterviewAnswer{Content: row.Content, CreatedAt: row.CreatedAt}
+	}
+
+	for i, row := range a.InterviewEvaluations {
+		s, ok := sessionIndex[row.SessionID]
+		if !ok {
+			invalid(entity.ExportTableInterviewEvaluations, i, "session_id", "does not match any interview session")
+			continue
+		}
+		if sessions[s].Evaluation != nil {
+			invalid(entity.ExportTableInterviewEvaluations, i, "session_id", "duplicates another evaluation of the session")
+			continue
+		}
+		if !validEvaluationRanks[row.TotalRank] {
+			invalid(entity.ExportTableInterviewEvaluations, i, "total_rank", "is invalid")
+		}
+		sessions[s].Evaluation = &entity.InterviewEvaluation{
+			TotalRank:      row.TotalRank,
+			TotalScore:     row.TotalScore,
+			OverallComment: row.OverallComment,
+			Scores:         row.EvaluationScores,
+			CreatedAt:      row.CreatedAt,
+		}
+	}
+
+	for i, row := range a.AnswerEvaluations {
+		ref, ok := questions[row.QuestionID]
+		if !ok || a.InterviewSessions[ref.session].ID != row.SessionID {
+			invalid(entity.ExportTableAnswerEvaluations, i, "question_id", "does not match any interview question of the session")
+			continue
+		}
+		evaluation := sessions[ref.session].Evaluation
+		if evaluation == nil {
+			invalid(entity.ExportTableAnswerEvaluations, i, "session_id", "does not match any interview evaluation")
+			continue
+		}
+		evaluation.AnswerEvaluations = append(evaluation.AnswerEvaluations, entity.AnswerEvaluation{
+			QuestionID:       row.QuestionID,
+			EvaluationScores: row.EvaluationScores,
+			QuestionComment:  row.QuestionComment,
+			Strengths:        row.Strengths,
+			Improvements:     row.Improvements,
+			CreatedAt:        row.CreatedAt,
+		})
+	}
+	return sessions, details
+}
+
+// validSessionStatuses・validEvaluationRanks はアーカイブの面接の記録に指定できるステータス・ランクです
+var (
+	validSessionStatuses = map[entity.InterviewSessionStatus]bool{
+		entity.InterviewSessionStatusCreated:          true,
+		entity.InterviewSessionStatusGreeting:         true,
+		entity.InterviewSessionStatusSelfIntroduction: true,
+		entity.InterviewSessionStatusIceBreak:         true,
+		entity.InterviewSessionStatusMain:             true,
+		entity.InterviewSessionStatusPaused:           true,
+		entity.InterviewSessionStatusCompleted:        true,
+		entity.InterviewSessionStatusTerminated:       true,
+		entity.InterviewSessionStatusClosing:          true,
+	}
+	validEvaluationRanks = map[entity.EvaluationRank]bool{
+		entity.EvaluationRankA: true,
+		entity.EvaluationRankB: true,
+		entity.EvaluationRankC: true,
+		entity.EvaluationRankD: true,
+		entity.EvaluationRankE: true,
+	}
+)
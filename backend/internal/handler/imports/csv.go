@@ -0,0 +1,227 @@
+package imports
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/apperror"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/handler/request"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/usecase/imports"
+)
+
+// CSV の列の対応先です。custom_field・job_custom_field は「:項目名」を付けると列名と異なる項目名にできます。
+const (
+	columnName                = "name"
+	columnBusinessDescription = "business_description"
+	columnJobTitle            = "job_title"
+	columnJobDescription      = "job_description"
+	columnCustomField         = "custom_field"
+	columnJobCustomField      = "job_custom_field"
+	columnIgnore              = "ignore"
+)
+
+// column は CSV の列の対応先です
+type column struct {
+	target string
+	// fieldName は追加情報の項目名です（custom_field・job_custom_field の場合）
+	fieldName string
+}
+
+// parseMultipartCSV は multipart/form-data の file の CSV を mapping（列名から対応先への JSON オブジェクト）に従って読み込みます
+func parseMultipartCSV(c *gin.Context) ([]entity.ImportCompany, []apperror.Detail, error) {
+	file, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return nil, nil, err
+		}
+		return nil, nil, apperror.Validation("file is required", apperror.Detail{Field: "file", Message: "is required"})
+	}
+
+	var mapping map[string]string
+	if raw := c.PostForm("mapping"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &mapping); err != nil {
+			return nil, nil, apperror.Validation("invalid mapping",
+				apperror.Detail{Field: "mapping", Message: "must be a JSON object of column names to targets"})
+		}
+	}
+
+	f, err := file.Open()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+	return parseCSV(f, mapping)
+}
+
+// parseCSV は1行目を列名とする CSV を読み込みます。
+// 企業名が同じ行は1件の企業にまとめ、企業の項目は最初の行の値を使用します。求人タイトルなど求人の列に値がある行は、その企業の求人情報になります。
+// mapping にない列は、列名が対応先と同じ場合はその対応先、それ以外は企業の追加情報（項目名は列名）として扱います。空のセルは無視します。
+func parseCSV(r io.Reader, mapping map[string]string) ([]entity.ImportCompany, []apperror.Detail, error) {
+	reader := csv.NewReader(r)
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil, apperror.Validation("CSV is empty", apperror.Detail{Field: "file", Message: "must have a header row"})
+	}
+	if err != nil {
+		return nil, nil, csvError(err)
+	}
+	if len(header) > 0 {
+		// Excel などが付ける BOM を取り除く
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	}
+	columns, err := mapColumns(header, mapping)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var companies []*entity.ImportCompany
+	var rowErrors []apperror.Detail
+	byName := map[string]*entity.ImportCompany{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, csvError(err)
+		}
+		row, _ := reader.FieldPos(0)
+
+		var company ImportCompanyRequest
+		var job ImportJobPostingRequest
+		hasJob := false
+		for i, value := range record {
+			value = strings.TrimSpace(value)
+			if value == "" {
+				continue
+			}
+			switch col := columns[i]; col.target {
+			case columnName:
+				company.Name = value
+			case columnBusinessDescription:
+				company.BusinessDescription = &value
+			case columnCustomField:
+				company.CustomFields = append(company.CustomFields, request.CustomField{FieldName: col.fieldName, Content: value})
+			case columnJobTitle:
+				job.Title, hasJob = value, true
+			case columnJobDescription:
+				job.Description, hasJob = &value, true
+			case columnJobCustomField:
+				job.CustomFields = append(job.CustomFields, request.CustomField{FieldName: col.fieldName, Content: value})
+				hasJob = true
+			}
+		}
+		if company.Name == "" && company.BusinessDescription == nil && company.CustomFields == nil && !hasJob {
+			// 空の行
+			continue
+		}
+
+		item, ok := byName[company.Name]
+		if !ok || company.Name == "" {
+			if err := binding.Validator.ValidateStruct(&company); err != nil {
+				details, err := imports.RowErrors(row, "", err)
+				if err != nil {
+					return nil, nil, err
+				}
+				rowErrors = append(rowErrors, details...)
+				continue
+			}
+			converted := company.toEntity(row)
+			item = &converted
+			byName[company.Name] = item
+			companies = append(companies, item)
+		}
+		if !hasJob {
+			continue
+		}
+		if err := binding.Validator.ValidateStruct(&job); err != nil {
+			details, err := imports.RowErrors(row, "job_posting", err)
+			if err != nil {
+				return nil, nil, err
+			}
+			rowErrors = append(rowErrors, details...)
+			continue
+		}
+		item.JobPostings = append(item.JobPostings, entity.ImportJobPosting{Row: row, Path: "job_posting", JobPosting: job.toEntity()})
+	}
+
+	result := make([]entity.ImportCompany, 0, len(companies))
+	for _, item := range companies {
+		result = append(result, *item)
+	}
+	return result, rowErrors, nil
+}
+
+// mapColumns は列名と mapping から各列の対応先を決めます。企業名の列がない場合・mapping が不正な場合は検証エラーを返します。
+func mapColumns(header []string, mapping map[string]string) ([]column, error) {
+	var details []apperror.Detail
+	headers := make(map[string]bool, len(header))
+	for _, name := range header {
+		headers[name] = true
+	}
+	for name := range mapping {
+		if !headers[name] {
+			details = append(details, apperror.Detail{Field: "mapping." + name, Message: "is not a column of the CSV"})
+		}
+	}
+
+	columns := make([]column, len(header))
+	hasName := false
+	for i, name := range header {
+		target, ok := mapping[name]
+		if !ok {
+			switch name {
+			case columnName, columnBusinessDescription, columnJobTitle, columnJobDescription:
+				target = name
+			default:
+				target = columnCustomField
+			}
+		}
+		target, fieldName, _ := strings.Cut(target, ":")
+		if fieldName == "" {
+			fieldName = strings.TrimSpace(name)
+		}
+		switch target {
+		case columnName:
+			hasName = true
+		case columnBusinessDescription, columnJobTitle, columnJobDescription, columnIgnore:
+		case columnCustomField, columnJobCustomField:
+			if fieldName == "" {
+				details = append(details, apperror.Detail{Field: "mapping", Message: "column " + strconv.Itoa(i+1) + " has no name for the custom field"})
+			}
+		default:
+			details = append(details, apperror.Detail{Field: "mapping." + name,
+				Message: "must be one of name business_description job_title job_description custom_field[:name] job_custom_field[:name] ignore"})
+		}
+		columns[i] = column{target: target, fieldName: fieldName}
+	}
+	if !hasName {
+		details = append(details, apperror.Detail{Field: "mapping", Message: "a column must be mapped to name"})
+	}
+	if len(details) > 0 {
+		return nil, apperror.Validation("invalid CSV columns", details...)
+	}
+	return columns, nil
+}
+
+// csvError は CSV の読み込みのエラーを変換します
+func csvError(err error) error {
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return apperror.Validation("CSV is malformed", apperror.Detail{
+			Field:   "rows[" + strconv.Itoa(parseErr.StartLine) + "]",
+			Message: parseErr.Err.Error(),
+		}).Wrap(err)
+	}
+	return err
+}
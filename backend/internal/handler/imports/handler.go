@@ -0,0 +1,295 @@
+package imports
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/apperror"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/handler/request"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/usecase/imports"
+)
+
+const (
+	// maxImportSize はインポートするファイル（リクエストボディ）の最大サイズです
+	maxImportSize = 5 << 20
+	// maxArchiveSize はエクスポートのアーカイブの最大サイズです。
+	// アーカイブは面接の記録を含むワークスペースの全件のため、企業数ではなくサイズのみで制限します。
+	maxArchiveSize = 100 << 20
+)
+
+type Handler interface {
+	Import(c *gin.Context)
+	ImportJobPostings(c *gin.Context)
+}
+
+type handler struct {
+	usecase imports.UseCase
+}
+
+func NewHandler(usecase imports.UseCase) Handler {
+	return &handler{usecase: usecase}
+}
+
+// ImportCompanyRequest は JSON でインポートする企業です。項目の規則は企業・求人情報の作成リクエストと同じです。
+type ImportCompanyRequest struct {
+	Name                string                    `json:"name" binding:"required,max=100"`
+	BusinessDescription *string                   `json:"business_description,omitempty" binding:"omitempty,max=1000"`
+	CustomFields        []request.CustomField     `json:"custom_fields,omitempty" binding:"omitempty,max=20,dive"`
+	JobPostings         []ImportJobPostingRequest `json:"job_postings,omitempty" binding:"omitempty,dive"`
+}
+
+type ImportJobPostingRequest struct {
+	Title        string                `json:"title" binding:"required,max=100"`
+	Description  *string               `json:"description,omitempty" binding:"omitempty,max=1000"`
+	CustomFields []request.CustomField `json:"custom_fields,omitempty" binding:"omitempty,max=20,dive"`
+}
+
+func (r ImportCompanyRequest) toEntity(row int) entity.ImportCompany {
+	company := entity.Company{Name: r.Name, BusinessDescription: r.BusinessDescription}
+	for _, field := range r.CustomFields {
+		company.CustomFields = append(company.CustomFields, entity.CompanyCustomField{FieldName: field.FieldName, Content: field.Content})
+	}
+	item := entity.ImportCompany{Row: row, Company: company}
+	for i, job := range r.JobPostings {
+		item.JobPostings = append(item.JobPostings, entity.ImportJobPosting{
+			Row:        row,
+			Path:       "job_postings[" + strconv.Itoa(i) + "]",
+			JobPosting: job.toEntity(),
+		})
+	}
+	return item
+}
+
+func (r ImportJobPostingRequest) toEntity() entity.JobPosting {
+	jobPosting := entity.JobPosting{Title: r.Title, Description: r.Description}
+	for _, field := range r.CustomFields {
+		jobPosting.CustomFields = append(jobPosting.CustomFields, entity.JobCustomField{FieldName: field.FieldName, Content: field.Content})
+	}
+	return jobPosting
+}
+
+// Import は企業と求人情報を一括で作成します。
+// JSON（application/json）は企業の配列またはエクスポートのアーカイブ（面接の記録を含む）、CSV は text/csv のボディまたは multipart/form-data の file（列の対応は mapping）で受け付けます。
+// dry_run=true の場合は作成せずに各行のエラーを返します。
+func (h *handler) Import(c *gin.Context) {
+	dryRun, err := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
+	if err != nil {
+		c.Error(apperror.Validation("invalid dry_run parameter", apperror.Detail{Field: "dry_run", Message: "must be true or false"}))
+		return
+	}
+
+	mediaType, _, err := mime.ParseMediaType(c.ContentType())
+	if err != nil {
+		c.Error(errUnsupportedImportType)
+		return
+	}
+	limit := int64(maxImportSize)
+	if mediaType == "application/json" {
+		// アーカイブかどうかは読み込むまで分からないため、大きい方の制限で読み込む
+		limit = maxArchiveSize
+	}
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+
+	var companies []entity.ImportCompany
+	var archive *entity.ImportArchive
+	var rowErrors []apperror.Detail
+	switch mediaType {
+	case "application/json":
+		companies, archive, rowErrors, err = parseJSON(c.Request.Body)
+	case "text/csv":
+		companies, rowErrors, err = parseCSV(c.Request.Body, nil)
+	case "multipart/form-data":
+		companies, rowErrors, err = parseMultipartCSV(c)
+	default:
+		err = errUnsupportedImportType
+	}
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			err = errImportTooLarge(maxBytesErr.Limit).Wrap(err)
+		}
+		c.Error(err)
+		return
+	}
+
+	var result *entity.ImportResult
+	if archive != nil {
+		result, err = h.usecase.ImportArchive(c.Request.Context(), archive, rowErrors, dryRun)
+	} else {
+		result, err = h.usecase.Import(c.Request.Context(), companies, rowErrors, dryRun)
+	}
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	status := http.StatusCreated
+	if dryRun {
+		status = http.StatusOK
+	}
+	c.JSON(status, result)
+}
+
+// ImportJobPostings は求人ページの HTML（text/html）または JSON-LD（application/ld+json・application/json）に含まれる
+// schema.org の JobPosting を求人情報として作成します。multipart/form-data の file も受け付けます。
+// 求人は hiringOrganization の名前が一致する企業に作成し、一致する企業がない場合は企業を作成します（company_id を指定した場合はその企業）。
+func (h *handler) ImportJobPostings(c *gin.Context) {
+	dryRun, err := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
+	if err != nil {
+		c.Error(apperror.Validation("invalid dry_run parameter", apperror.Detail{Field: "dry_run", Message: "must be true or false"}))
+		return
+	}
+	companyID := 0
+	if raw := c.Query("company_id"); raw != "" {
+		if companyID, err = strconv.Atoi(raw); err != nil || companyID < 1 {
+			c.Error(apperror.Validation("invalid company_id parameter", apperror.Detail{Field: "company_id", Message: "must be a positive integer"}))
+			return
+		}
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize)
+	nodes, err := readJobPostingNodes(c)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			err = errImportTooLarge(maxBytesErr.Limit).Wrap(err)
+		}
+		c.Error(err)
+		return
+	}
+
+	items := make([]entity.ImportLinkedJobPosting, 0, len(nodes))
+	var rowErrors []apperror.Detail
+	for i, node := range nodes {
+		item, details := mapJobPosting(i+1, node, companyID)
+		if len(details) > 0 {
+			rowErrors = append(rowErrors, details...)
+			continue
+		}
+		items = append(items, item)
+	}
+
+	result, err := h.usecase.ImportJobPostings(c.Request.Context(), items, rowErrors, dryRun)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	status := http.StatusCreated
+	if dryRun {
+		status = http.StatusOK
+	}
+	c.JSON(status, result)
+}
+
+// readJobPostingNodes はリクエストの HTML・JSON-LD から JobPosting のノードを読み込みます。
+// multipart/form-data の file は内容が { または [ で始まる場合は JSON-LD、それ以外は HTML として扱います。
+func readJobPostingNodes(c *gin.Context) ([]jsonLDNode, error) {
+	mediaType, _, err := mime.ParseMediaType(c.ContentType())
+	if err != nil {
+		return nil, errUnsupportedJobPostingImportType
+	}
+
+	var data []byte
+	switch mediaType {
+	case "text/html", "application/ld+json", "application/json":
+		data, err = io.ReadAll(c.Request.Body)
+	case "multipart/form-data":
+		data, err = readFormFile(c)
+	default:
+		return nil, errUnsupportedJobPostingImportType
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	data = bytes.TrimPrefix(data, []byte("\ufeff"))
+	if mediaType == "text/html" {
+		return parseJobPostingHTML(data)
+	}
+	if trimmed := bytes.TrimSpace(data); mediaType == "multipart/form-data" && (len(trimmed) == 0 || (trimmed[0] != '{' && trimmed[0] != '[')) {
+		return parseJobPostingHTML(data)
+	}
+	return parseJobPostingJSONLD(data)
+}
+
+// readFormFile は multipart/form-data の file の内容を読み込みます
+func readFormFile(c *gin.Context) ([]byte, error) {
+	file, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return nil, err
+		}
+		return nil, apperror.Validation("file is required", apperror.Detail{Field: "file", Message: "is required"})
+	}
+	f, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return io.ReadAll(f)
+}
+
+// errUnsupportedJobPostingImportType は求人情報の取り込みに対応していない形式のリクエストの場合に返されます
+var errUnsupportedJobPostingImportType = apperror.New(apperror.CodeUnsupportedMediaType,
+	"content type must be text/html, application/ld+json, application/json or multipart/form-data")
+
+// errImportTooLarge は limit バイトを超えるファイルの場合に返されます
+func errImportTooLarge(limit int64) *apperror.Error {
+	return apperror.PayloadTooLarge(fmt.Sprintf("import file is too large (max %dMB)", limit>>20))
+}
+
+// errUnsupportedImportType はインポートに対応していない形式のリクエストの場合に返されます
+var errUnsupportedImportType = apperror.New(apperror.CodeUnsupportedMediaType,
+	"content type must be application/json, text/csv or multipart/form-data")
+
+// parseJSON は企業の配列、またはエクスポートのアーカイブ（GET /api/v1/exports?format=json）を読み込みます。
+// アーカイブの場合は archive、企業の配列の場合は companies を返します。
+// 各要素は個別に検証し、不正な要素は行のエラーとして返します。
+func parseJSON(body io.Reader) (companies []entity.ImportCompany, archive *entity.ImportArchive, rowErrors []apperror.Detail, err error) {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, nil, nil, apperror.FromBinding(err)
+	}
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		archive, rowErrors, err = parseArchive(trimmed)
+		return nil, archive, rowErrors, err
+	}
+	// 企業の配列はアーカイブ以外の形式と同じ制限にする
+	if len(data) > maxImportSize {
+		return nil, nil, nil, &http.MaxBytesError{Limit: maxImportSize}
+	}
+
+	var elements []json.RawMessage
+	if err := json.Unmarshal(data, &elements); err != nil {
+		return nil, nil, nil, apperror.FromBinding(err)
+	}
+	if elements == nil {
+		return nil, nil, nil, apperror.Validation("request body must be a JSON array of companies")
+	}
+
+	for i, element := range elements {
+		row := i + 1
+		var req ImportCompanyRequest
+		if err := request.DecodeStrictJSON(bytes.NewReader(element), &req); err != nil {
+			details, err := imports.RowErrors(row, "", err)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			rowErrors = append(rowErrors, details...)
+			continue
+		}
+		companies = append(companies, req.toEntity(row))
+	}
+	return companies, nil, rowErrors, nil
+}
@@ -0,0 +1,405 @@
+package imports
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/apperror"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/htmldoc"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/jobparse"
+)
+
+// jsonLDNode は JSON-LD のノード（プロパティ名から値へのオブジェクト）です
+type jsonLDNode = map[string]interface{}
+
+// errNoJobPosting は入力に schema.org の JobPosting が含まれない場合に返されます
+var errNoJobPosting = apperror.Validation("no schema.org JobPosting found",
+	apperror.Detail{Field: "body", Message: "must contain a JSON-LD JobPosting"})
+
+// parseJobPostingHTML は HTML の script type="application/ld+json" から JobPosting を読み込みます。
+// 求人ページには求人以外の構造化データも含まれるため、JSON として不正なブロックは読み飛ばします。
+func parseJobPostingHTML(data []byte) ([]jsonLDNode, error) {
+	doc, err := htmldoc.Parse(bytes.NewReader(data))
+	if err != nil {
+		return nil, apperror.Validation("invalid html", apperror.Detail{Field: "body", Message: "could not be parsed"})
+	}
+	var postings []jsonLDNode
+	for _, block := range doc.JSONLD {
+		var value interface{}
+		if err := decodeJSONLD([]byte(block), &value); err != nil {
+			continue
+		}
+		postings = appendJobPostings(postings, value)
+	}
+	if len(postings) == 0 {
+		return nil, errNoJobPosting
+	}
+	return postings, nil
+}
+
+// parseJobPostingJSONLD は JSON-LD（オブジェクト・配列・@graph）から JobPosting を読み込みます
+func parseJobPostingJSONLD(data []byte) ([]jsonLDNode, error) {
+	var value interface{}
+	if err := decodeJSONLD(data, &value); err != nil {
+		return nil, apperror.FromBinding(err)
+	}
+	postings := appendJobPostings(nil, value)
+	if len(postings) == 0 {
+		return nil, errNoJobPosting
+	}
+	return postings, nil
+}
+
+// decodeJSONLD は金額を正確に読み取るため、数値を json.Number として読み込みます
+func decodeJSONLD(data []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+// appendJobPostings は value と、その配列・@graph に含まれる JobPosting のノードを postings に追加します
+func appendJobPostings(postings []jsonLDNode, value interface{}) []jsonLDNode {
+	switch v := value.(type) {
+	case []interface{}:
+		for _, item := range v {
+			postings = appendJobPostings(postings, item)
+		}
+	case jsonLDNode:
+		if hasType(v, "JobPosting") {
+			postings = append(postings, v)
+		}
+		if graph, ok := v["@graph"]; ok {
+			postings = appendJobPostings(postings, graph)
+		}
+	}
+	return postings
+}
+
+// hasType はノードの @type に schema.org の型 name が含まれるかどうかを返します（schema: や URL の接頭辞も許容します）
+func hasType(node jsonLDNode, name string) bool {
+	var types []interface{}
+	switch t := node["@type"].(type) {
+	case string:
+		types = []interface{}{t}
+	case []interface{}:
+		types = t
+	}
+	for _, t := range types {
+		s, _ := t.(string)
+		s = strings.TrimPrefix(s, "schema:")
+		s = strings.TrimPrefix(strings.TrimPrefix(s, "http://schema.org/"), "https://schema.org/")
+		if s == name {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonLDKeywords は JSON-LD の構文のプロパティです。取り込まなかったプロパティには含めません。
+var jsonLDKeywords = map[string]bool{"@context": true, "@type": true, "@id": true}
+
+// mappedProperties は求人情報に取り込む JobPosting のプロパティです
+var mappedProperties = map[string]bool{
+	"title": true, "description": true, "hiringOrganization": true, "employmentType": true,
+	"baseSalary": true, "jobLocation": true, "jobLocationType": true, "qualifications": true,
+}
+
+// employmentTypeLabels は schema.org の employmentType の値の表記です
+var employmentTypeLabels = map[string]string{
+	"FULL_TIME":  "正社員",
+	"PART_TIME":  "パート・アルバイト",
+	"CONTRACTOR": "業務委託",
+	"TEMPORARY":  "契約社員",
+	"INTERN":     "インターン",
+	"VOLUNTEER":  "ボランティア",
+	"PER_DIEM":   "日雇い",
+	"OTHER":      "その他",
+}
+
+// salaryUnits は schema.org の unitText の値と給与の単位の対応です
+var salaryUnits = map[string]entity.SalaryUnit{
+	"YEAR": entity.SalaryUnitYear, "MONTH": entity.SalaryUnitMonth, "WEEK": entity.SalaryUnitWeek,
+	"DAY": entity.SalaryUnitDay, "HOUR": entity.SalaryUnitHour,
+}
+
+// jobPostingMapper は JobPosting のノードを求人情報に変換し、取り込まなかったプロパティを記録します
+type jobPostingMapper struct {
+	item    entity.ImportLinkedJobPosting
+	details []apperror.Detail
+}
+
+// ignore は取り込まなかったプロパティを記録します
+func (m *jobPostingMapper) ignore(name string) {
+	m.item.Ignored = append(m.item.Ignored, name)
+}
+
+// invalid は取り込めない値のプロパティを行のエラーとして記録します
+func (m *jobPostingMapper) invalid(field, message string) {
+	m.details = append(m.details, apperror.Detail{Field: fmt.Sprintf("rows[%d].%s", m.item.Row, field), Message: message})
+}
+
+// addField は求人の追加情報を追加します。内容は作成リクエストの制限（500文字）に切り詰めます。
+func (m *jobPostingMapper) addField(name, content string) {
+	if content = strings.TrimSpace(content); content != "" {
+		m.item.JobPosting.CustomFields = append(m.item.JobPosting.CustomFields, entity.JobCustomField{
+			FieldName: name,
+			Content:   htmldoc.Truncate(content, 500),
+		})
+	}
+}
+
+// mapJobPosting は JobPosting のノードを、企業名で紐づけて作成する求人情報に変換します。
+// companyID が 0 でない場合は hiringOrganization を使用せず、その企業に作成します。
+// 説明文は HTML のタグを取り除き、タイトル・説明文は作成リクエストの制限（100文字・1000文字）に切り詰めます。
+func mapJobPosting(row int, node jsonLDNode, companyID int) (entity.ImportLinkedJobPosting, []apperror.Detail) {
+	m := &jobPostingMapper{item: entity.ImportLinkedJobPosting{Row: row}}
+	m.item.JobPosting.CompanyID = companyID
+
+	m.item.JobPosting.Title = htmldoc.Truncate(stripHTML(text(node["title"])), 100)
+	if m.item.JobPosting.Title == "" {
+		m.invalid("title", "is required")
+	}
+	if description := stripHTML(text(node["description"])); description != "" {
+		description = htmldoc.Truncate(description, 1000)
+		m.item.JobPosting.Description = &description
+	}
+
+	if companyID != 0 {
+		if _, ok := node["hiringOrganization"]; ok {
+			m.ignore("hiringOrganization")
+		}
+	} else {
+		m.mapOrganization(node["hiringOrganization"])
+	}
+
+	m.addField(entity.JobFieldEmploymentType, employmentType(node["employmentType"]))
+	if salary, ok := baseSalary(node["baseSalary"]); ok {
+		m.addField(entity.JobFieldSalary, jobparse.FormatSalary(salary))
+	} else if _, exists := node["baseSalary"]; exists {
+		m.ignore("baseSalary")
+	}
+	m.addField(entity.JobFieldLocation, jobLocation(node["jobLocation"], node["jobLocationType"]))
+	m.addField(entity.JobFieldRequiredSkills, bulletList(texts(node["qualifications"])))
+
+	for name := range node {
+		if !jsonLDKeywords[name] && !mappedProperties[name] {
+			m.ignore(name)
+		}
+	}
+	sort.Strings(m.item.Ignored)
+	if m.item.Ignored == nil {
+		m.item.Ignored = []string{}
+	}
+	return m.item, m.details
+}
+
+// mapOrganization は hiringOrganization（Organization または企業名の文字列）の名前を、紐づける企業の名前にします。
+// 名前以外のプロパティは取り込みません。配列の場合は先頭の組織を使用し、2件目以降を ignored に hiringOrganization[n] として記録します。
+func (m *jobPostingMapper) mapOrganization(value interface{}) {
+	var name string
+	switch v := value.(type) {
+	case string:
+		name = v
+	case jsonLDNode:
+		name = text(v["name"])
+		for key := range v {
+			if key != "name" && !jsonLDKeywords[key] {
+				m.ignore("hiringOrganization." + key)
+			}
+		}
+	case []interface{}:
+		if len(v) == 0 {
+			break
+		}
+		// 複数の組織がある場合は先頭の組織に紐づけ、残りは取り込まなかったプロパティとして報告する
+		m.mapOrganization(v[0])
+		for i := 1; i < len(v); i++ {
+			m.ignore(fmt.Sprintf("hiringOrganization[%d]", i))
+		}
+		return
+	}
+	name = strings.TrimSpace(stripHTML(name))
+	switch {
+	case name == "":
+		m.invalid("hiringOrganization.name", "is required")
+	case utf8.RuneCountInString(name) > 100:
+		m.invalid("hiringOrganization.name", "must be at most 100 characters")
+	}
+	m.item.Company.Name = name
+}
+
+// employmentType は employmentType（文字列または配列）を表記に変換して「、」で区切ります。定義されていない値はそのまま使用します。
+func employmentType(value interface{}) string {
+	var labels []string
+	for _, t := range texts(value) {
+		if label, ok := employmentTypeLabels[strings.ToUpper(strings.ReplaceAll(t, "-", "_"))]; ok {
+			t = label
+		}
+		labels = append(labels, t)
+	}
+	return strings.Join(labels, "、")
+}
+
+// baseSalary は baseSalary（MonetaryAmount）を給与の範囲に変換します。
+// value は数値・文字列・QuantitativeValue（value または minValue・maxValue と unitText）に対応します。
+func baseSalary(value interface{}) (*entity.SalaryRange, bool) {
+	amount, ok := value.(jsonLDNode)
+	if !ok {
+		// 「年収500万円〜」などの表記のみの場合
+		if s := strings.TrimSpace(text(value)); s != "" {
+			return &entity.SalaryRange{Text: s}, true
+		}
+		return nil, false
+	}
+	salary := &entity.SalaryRange{Currency: strings.ToUpper(text(amount["currency"]))}
+	switch v := amount["value"].(type) {
+	case jsonLDNode:
+		salary.Unit = salaryUnits[strings.ToUpper(text(v["unitText"]))]
+		if n, ok := number(v["value"]); ok {
+			salary.Min, salary.Max = &n, &n
+		} else {
+			if n, ok := number(v["minValue"]); ok {
+				salary.Min = &n
+			}
+			if n, ok := number(v["maxValue"]); ok {
+				salary.Max = &n
+			}
+		}
+	default:
+		if n, ok := number(v); ok {
+			salary.Min, salary.Max = &n, &n
+		}
+	}
+	if salary.Min == nil && salary.Max == nil {
+		return nil, false
+	}
+	return salary, true
+}
+
+// jobLocation は jobLocation（Place または配列）の住所を「 / 」で区切ります。
+// jobLocationType が TELECOMMUTE の場合はリモート勤務を加えます。
+func jobLocation(value, locationType interface{}) string {
+	var locations []string
+	places, ok := value.([]interface{})
+	if !ok && value != nil {
+		places = []interface{}{value}
+	}
+	for _, place := range places {
+		if location := placeText(place); location != "" {
+			locations = append(locations, location)
+		}
+	}
+	for _, t := range texts(locationType) {
+		if strings.EqualFold(t, "TELECOMMUTE") {
+			locations = append(locations, "リモート勤務可")
+			break
+		}
+	}
+	return strings.Join(locations, " / ")
+}
+
+// placeText は Place の住所（PostalAddress は都道府県・市区町村・番地の順）または名前を返します
+func placeText(value interface{}) string {
+	place, ok := value.(jsonLDNode)
+	if !ok {
+		return strings.TrimSpace(text(value))
+	}
+	switch address := place["address"].(type) {
+	case jsonLDNode:
+		var parts []string
+		if country := text(address["addressCountry"]); country != "" && !strings.EqualFold(country, "JP") && country != "日本" {
+			parts = append(parts, country)
+		}
+		for _, key := range []string{"addressRegion", "addressLocality", "streetAddress"} {
+			if part := strings.TrimSpace(text(address[key])); part != "" {
+				parts = append(parts, part)
+			}
+		}
+		if len(parts) > 0 {
+			return strings.Join(parts, " ")
+		}
+	case string:
+		if strings.TrimSpace(address) != "" {
+			return strings.TrimSpace(address)
+		}
+	}
+	return strings.TrimSpace(text(place["name"]))
+}
+
+// text は文字列・数値の値、またはノードの name を文字列で返します
+func text(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case json.Number:
+		return v.String()
+	case jsonLDNode:
+		return text(v["name"])
+	}
+	return ""
+}
+
+// texts は値または値の配列を、HTML のタグを取り除いた文字列の一覧にします。
+// 空でない複数行の文字列（HTML の箇条書きなど）は行ごとに分けます。
+func texts(value interface{}) []string {
+	values, ok := value.([]interface{})
+	if !ok {
+		values = []interface{}{value}
+	}
+	var result []string
+	for _, v := range values {
+		s := text(v)
+		if node, ok := v.(jsonLDNode); ok && s == "" {
+			// EducationalOccupationalCredential などの名前のないノードは説明を使用する
+			s = text(node["description"])
+		}
+		for _, line := range strings.Split(stripHTML(s), "\n") {
+			if line = strings.TrimSpace(strings.TrimLeft(line, "・-*• ")); line != "" {
+				result = append(result, line)
+			}
+		}
+	}
+	return result
+}
+
+// number は数値・数値の文字列を整数に丸めて返します
+func number(value interface{}) (int, bool) {
+	var f float64
+	var err error
+	switch v := value.(type) {
+	case json.Number:
+		f, err = v.Float64()
+	case string:
+		f, err = json.Number(strings.ReplaceAll(strings.TrimSpace(v), ",", "")).Float64()
+	default:
+		return 0, false
+	}
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, false
+	}
+	return int(math.Round(f)), true
+}
+
+// stripHTML は HTML のタグを取り除きます。文字参照でエスケープされた HTML（&lt;p&gt; など）も取り除きます。
+func stripHTML(s string) string {
+	s = htmldoc.StripTags(s)
+	if strings.Contains(s, "<") {
+		s = htmldoc.StripTags(s)
+	}
+	return s
+}
+
+// bulletList は項目を「・」で始まる行にまとめます
+func bulletList(items []string) string {
+	lines := make([]string, len(items))
+	for i, item := range items {
+		lines[i] = "・" + item
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,60 @@
+package interview_evaluation
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/apperror"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/usecase/interview_evaluation"
+)
+
+type Handler interface {
+	Evaluate(c *gin.Context)
+	GetEvaluation(c *gin.Context)
+}
+
+type handler struct {
+	usecase interview_evaluation.UseCase
+}
+
+func NewHandler(usecase interview_evaluation.UseCase) Handler {
+	return &handler{usecase: usecase}
+}
+
+func (h *handler) Evaluate(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.InvalidParameter("id"))
+		return
+	}
+
+	job, err := h.usecase.RequestEvaluation(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	// 評価は非同期で実行されるため、ジョブの状態は GET /api/v1/jobs/:id で確認する
+	c.Header("Location", fmt.Sprintf("/api/v1/jobs/%d", job.ID))
+	c.JSON(http.StatusAccepted, gin.H{"job_id": job.ID, "status": job.Status})
+}
+
+func (h *handler) GetEvaluation(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.InvalidParameter("id"))
+		return
+	}
+
+	evaluation, err := h.usecase.GetEvaluation(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entity.InterviewEvaluationResponse{Evaluation: evaluation})
+}
@@ -0,0 +1,205 @@
+package interview_session
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/apperror"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/usecase/interview_session"
+)
+
+type Handler interface {
+	CreateSession(c *gin.Context)
+	GetGreeting(c *gin.Context)
+	SubmitSelfIntroduction(c *gin.Context)
+	SubmitIceBreak(c *gin.Context)
+	SubmitQuestion(c *gin.Context)
+	StreamQuestion(c *gin.Context)
+	PauseSession(c *gin.Context)
+	ResumeSession(c *gin.Context)
+	TerminateSession(c *gin.Context)
+}
+
+type handler struct {
+	usecase interview_session.UseCase
+}
+
+func NewHandler(usecase interview_session.UseCase) Handler {
+	return &handler{usecase: usecase}
+}
+
+type CreateInterviewSessionRequest struct {
+	CompanyID               *int    `json:"company_id,omitempty"`
+	JobPostingID            *int    `json:"job_posting_id,omitempty"`
+	InterviewPhase          *string `json:"interview_phase,omitempty" binding:"omitempty,max=100"`
+	InterviewerRole         *string `json:"interviewer_role,omitempty" binding:"omitempty,max=100"`
+	QuestionCount           int     `json:"question_count" binding:"required,oneof=5 10 15"`
+	IncludeSelfIntroduction *bool   `json:"include_self_introduction" binding:"required"`
+	IncludeIceBreak         *bool   `json:"include_ice_break" binding:"required"`
+}
+
+type SubmitAnswerRequest struct {
+	PreviousAnswer string `json:"previous_answer" binding:"required,max=10000"`
+	CurrentStatus  string `json:"current_status" binding:"required"`
+}
+
+func (h *handler) CreateSession(c *gin.Context) {
+	var req CreateInterviewSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	session := &entity.InterviewSession{
+		CompanyID:               req.CompanyID,
+		JobPostingID:            req.JobPostingID,
+		InterviewPhase:          req.InterviewPhase,
+		InterviewerRole:         req.InterviewerRole,
+		QuestionCount:           req.QuestionCount,
+		IncludeSelfIntroduction: *req.IncludeSelfIntroduction,
+		IncludeIceBreak:         *req.IncludeIceBreak,
+	}
+
+	if err := h.usecase.CreateSession(c.Request.Context(), session); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, session)
+}
+
+func (h *handler) GetGreeting(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.InvalidParameter("id"))
+		return
+	}
+
+	result, err := h.usecase.GetGreeting(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *handler) SubmitSelfIntroduction(c *gin.Context) {
+	h.submitAnswer(c, h.usecase.SubmitSelfIntroduction)
+}
+
+func (h *handler) SubmitIceBreak(c *gin.Context) {
+	h.submitAnswer(c, h.usecase.SubmitIceBreak)
+}
+
+func (h *handler) SubmitQuestion(c *gin.Context) {
+	h.submitAnswer(c, h.usecase.SubmitQuestion)
+}
+
+// StreamQuestion は SubmitQuestion のストリーミング版です。
+// 生成中の発話を delta イベント、保存された次の質問を done イベント、失敗を error イベントとして text/event-stream で返します。
+func (h *handler) StreamQuestion(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.InvalidParameter("id"))
+		return
+	}
+
+	var req SubmitAnswerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	// クライアントが途中で切断しても回答と次の質問は必ず保存するため、キャンセルを伝播しない ctx で生成する
+	clientCtx := c.Request.Context()
+	ctx := context.WithoutCancel(clientCtx)
+
+	onDelta := func(delta string) error {
+		if clientCtx.Err() != nil {
+			return nil
+		}
+		c.SSEvent("delta", gin.H{"content": delta})
+		c.Writer.Flush()
+		return nil
+	}
+
+	result, err := h.usecase.StreamQuestion(ctx, id, entity.InterviewSessionStatus(req.CurrentStatus), req.PreviousAnswer, onDelta)
+	if clientCtx.Err() != nil {
+		return
+	}
+	if err != nil {
+		appErr := apperror.From(err)
+		c.SSEvent("error", gin.H{"status": appErr.Status(), "error": appErr})
+		c.Writer.Flush()
+		return
+	}
+
+	c.SSEvent("done", result)
+	c.Writer.Flush()
+}
+
+func (h *handler) PauseSession(c *gin.Context) {
+	h.transition(c, h.usecase.PauseSession)
+}
+
+func (h *handler) ResumeSession(c *gin.Context) {
+	h.transition(c, h.usecase.ResumeSession)
+}
+
+func (h *handler) TerminateSession(c *gin.Context) {
+	h.transition(c, h.usecase.TerminateSession)
+}
+
+type transitionFunc func(ctx context.Context, id int) (*entity.InterviewProgressResponse, error)
+
+func (h *handler) transition(c *gin.Context, transition transitionFunc) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.InvalidParameter("id"))
+		return
+	}
+
+	result, err := transition(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+type submitFunc func(ctx context.Context, id int, currentStatus entity.InterviewSessionStatus, previousAnswer string) (*entity.InterviewProgressResponse, error)
+
+func (h *handler) submitAnswer(c *gin.Context, submit submitFunc) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.InvalidParameter("id"))
+		return
+	}
+
+	var req SubmitAnswerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	result, err := submit(c.Request.Context(), id, entity.InterviewSessionStatus(req.CurrentStatus), req.PreviousAnswer)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
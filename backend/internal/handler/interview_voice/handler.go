@@ -0,0 +1,284 @@
+package interview_voice
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/apperror"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/speech"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/usecase/interview_session"
+	speechUsecase "github.com/takanoakira/ai-interview-practice/backend/internal/usecase/speech"
+)
+
+// sentenceTerminators は音声合成を行う区切りとなる文字です
+const sentenceTerminators = "。！？!?\n"
+
+// errRecordingNotStarted は start を送信せずに音声・stop を送信した場合のエラーです
+var errRecordingNotStarted = apperror.BadRequest("recording has not started")
+
+type Handler interface {
+	Connect(c *gin.Context)
+}
+
+type handler struct {
+	sessionUsecase interview_session.UseCase
+	speechUsecase  speechUsecase.UseCase
+	upgrader       websocket.Upgrader
+}
+
+// NewHandler は checkOrigin で許可された接続元からの WebSocket 接続を受け付けるハンドラーを返します。
+// checkOrigin には CORS と同じ許可オリジンの判定を渡します。
+func NewHandler(sessionUsecase interview_session.UseCase, speechUsecase speechUsecase.UseCase, checkOrigin func(r *http.Request) bool) Handler {
+	return &handler{
+		sessionUsecase: sessionUsecase,
+		speechUsecase:  speechUsecase,
+		upgrader: websocket.Upgrader{
+			CheckOrigin: checkOrigin,
+		},
+	}
+}
+
+// クライアントから送信される制御メッセージの種別
+const (
+	// messageGreeting は面接を開始し、挨拶を要求します
+	messageGreeting = "greeting"
+	// messageStart は発話の開始を通知します。以降のバイナリメッセージが音声データとして連結されます。
+	messageStart = "start"
+	// messageStop は発話の終了を通知します。音声認識を行い、回答として送信します。
+	messageStop = "stop"
+	// messageCancel は受信中の音声データを破棄します
+	messageCancel = "cancel"
+)
+
+// controlMessage はクライアントから送信される制御メッセージです
+type controlMessage struct {
+	Type          string `json:"type"`
+	Filename      string `json:"filename,omitempty"`
+	MimeType      string `json:"mime_type,omitempty"`
+	Language      string `json:"language,omitempty"`
+	CurrentStatus string `json:"current_status,omitempty"`
+}
+
+// serverMessage はサーバーから送信するメッセージです。
+// type が "audio" のメッセージの直後には、合成音声がバイナリメッセージとして送信されます。
+type serverMessage struct {
+	Type       string                            `json:"type"`
+	Content    string                            `json:"content,omitempty"`
+	Transcript *speech.Transcript                `json:"transcript,omitempty"`
+	Greeting   *entity.GreetingResponse          `json:"greeting,omitempty"`
+	Progress   *entity.InterviewProgressResponse `json:"progress,omitempty"`
+	MimeType   string                            `json:"mime_type,omitempty"`
+	Sequence   int                               `json:"sequence,omitempty"`
+	Status     int                               `json:"status,omitempty"`
+	Error      *apperror.Error                   `json:"error,omitempty"`
+}
+
+// Connect は音声面接用の WebSocket 接続を確立します。
+// 音声（バイナリ）と制御メッセージ（JSON テキスト）を受け付け、
+// 音声認識 → 回答送信 → 次の質問の生成 → 音声合成 の流れを1つの接続で処理します。
+func (h *handler) Connect(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.InvalidParameter("id"))
+		return
+	}
+
+	conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// Upgrade が失敗した場合はレスポンスが書き込み済み
+		log.Printf("interview_voice: upgrade failed: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	conn.SetReadLimit(speech.MaxAudioBytes)
+	// 接続が切断されても回答と次の質問は必ず保存するため、キャンセルを伝播しない ctx で処理する。
+	// 認証済みのユーザーなど ctx の値は引き継ぐ。
+	s := &voiceSession{handler: h, ctx: context.WithoutCancel(c.Request.Context()), conn: conn, sessionID: id}
+	s.run()
+}
+
+// voiceSession は1つの WebSocket 接続の状態です
+type voiceSession struct {
+	*handler
+	ctx       context.Context
+	conn      *websocket.Conn
+	sessionID int
+	closed    bool
+
+	// 受信中の発話
+	recording bool
+	audio     speech.Audio
+}
+
+func (s *voiceSession) run() {
+	for {
+		messageType, data, err := s.conn.ReadMessage()
+		if err != nil {
+			s.closed = true
+			if !websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				log.Printf("interview_voice: read failed: %v", err)
+			}
+			return
+		}
+
+		switch messageType {
+		case websocket.BinaryMessage:
+			s.appendAudio(data)
+		case websocket.TextMessage:
+			var msg controlMessage
+			if err := json.Unmarshal(data, &msg); err != nil {
+				s.sendError(apperror.BadRequest("invalid control message"))
+				continue
+			}
+			s.handleControl(msg)
+		}
+	}
+}
+
+func (s *voiceSession) handleControl(msg controlMessage) {
+	ctx := s.ctx
+
+	switch msg.Type {
+	case messageGreeting:
+		result, err := s.sessionUsecase.GetGreeting(ctx, s.sessionID)
+		if err != nil {
+			s.sendUsecaseError(err)
+			return
+		}
+		s.send(serverMessage{Type: "greeting", Greeting: result})
+		s.speak(ctx, result.Question.Content)
+	case messageStart:
+		s.recording = true
+		s.audio = speech.Audio{Filename: msg.Filename, MimeType: msg.MimeType, Language: msg.Language}
+	case messageStop:
+		if !s.recording {
+			s.sendError(errRecordingNotStarted)
+			return
+		}
+		audio := s.audio
+		s.recording = false
+		s.audio = speech.Audio{}
+		s.answer(ctx, audio, entity.InterviewSessionStatus(msg.CurrentStatus))
+	case messageCancel:
+		s.recording = false
+		s.audio = speech.Audio{}
+	default:
+		s.sendError(apperror.BadRequest(fmt.Sprintf("unknown message type: %s", msg.Type)))
+	}
+}
+
+func (s *voiceSession) appendAudio(data []byte) {
+	if !s.recording {
+		s.sendError(errRecordingNotStarted)
+		return
+	}
+	if len(s.audio.Data)+len(data) > speech.MaxAudioBytes {
+		s.recording = false
+		s.audio = speech.Audio{}
+		s.sendError(speech.ErrAudioTooLarge)
+		return
+	}
+	s.audio.Data = append(s.audio.Data, data...)
+}
+
+// answer は音声を認識し、認識結果を回答として送信します。次の質問は生成中に逐次送信・音声合成します。
+func (s *voiceSession) answer(ctx context.Context, audio speech.Audio, currentStatus entity.InterviewSessionStatus) {
+	transcript, err := s.speechUsecase.Transcribe(ctx, audio)
+	if err != nil {
+		s.sendUsecaseError(err)
+		return
+	}
+	s.send(serverMessage{Type: "transcript", Transcript: transcript})
+
+	var pending strings.Builder
+	sequence := 0
+	onDelta := func(delta string) error {
+		s.send(serverMessage{Type: "delta", Content: delta})
+
+		// 文の区切りごとに音声合成して返す
+		pending.WriteString(delta)
+		text := pending.String()
+		if i := strings.LastIndexAny(text, sentenceTerminators); i >= 0 {
+			_, size := utf8.DecodeRuneInString(text[i:])
+			sentence := text[:i+size]
+			pending.Reset()
+			pending.WriteString(text[len(sentence):])
+			sequence++
+			s.speakSegment(ctx, sentence, sequence)
+		}
+		return nil
+	}
+
+	result, err := s.sessionUsecase.SubmitAnswer(ctx, s.sessionID, currentStatus, transcript.Text, onDelta)
+	if err != nil {
+		s.sendUsecaseError(err)
+		return
+	}
+	if rest := pending.String(); result.NextQuestion != nil && strings.TrimSpace(rest) != "" {
+		sequence++
+		s.speakSegment(ctx, rest, sequence)
+	}
+	s.send(serverMessage{Type: "progress", Progress: result})
+}
+
+// speak は text 全体を1つの音声として送信します
+func (s *voiceSession) speak(ctx context.Context, text string) {
+	s.speakSegment(ctx, text, 1)
+}
+
+// speakSegment は text を音声合成し、audio メッセージに続けてバイナリメッセージで送信します
+func (s *voiceSession) speakSegment(ctx context.Context, text string, sequence int) {
+	text = strings.TrimSpace(text)
+	if text == "" || s.closed {
+		return
+	}
+	audio, err := s.speechUsecase.Synthesize(ctx, speech.SynthesisRequest{Text: text})
+	if err != nil {
+		s.sendUsecaseError(err)
+		return
+	}
+	s.send(serverMessage{Type: "audio", Content: text, MimeType: audio.MimeType, Sequence: sequence})
+	s.write(websocket.BinaryMessage, audio.Data)
+}
+
+func (s *voiceSession) send(msg serverMessage) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		log.Printf("interview_voice: marshal message: %v", err)
+		return
+	}
+	s.write(websocket.TextMessage, data)
+}
+
+func (s *voiceSession) write(messageType int, data []byte) {
+	if s.closed {
+		return
+	}
+	if err := s.conn.WriteMessage(messageType, data); err != nil {
+		// 書き込みに失敗した場合は以降の送信を行わず、処理は最後まで続ける
+		s.closed = true
+	}
+}
+
+func (s *voiceSession) sendError(err *apperror.Error) {
+	s.send(serverMessage{Type: "error", Status: err.Status(), Error: err})
+}
+
+func (s *voiceSession) sendUsecaseError(err error) {
+	appErr := apperror.From(err)
+	if appErr.Code == apperror.CodeInternal {
+		log.Printf("interview_voice: %v", err)
+	}
+	s.sendError(appErr)
+}
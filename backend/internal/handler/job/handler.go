@@ -0,0 +1,39 @@
+package job
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/apperror"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/usecase/job"
+)
+
+type Handler interface {
+	GetJob(c *gin.Context)
+}
+
+type handler struct {
+	usecase job.UseCase
+}
+
+func NewHandler(usecase job.UseCase) Handler {
+	return &handler{usecase: usecase}
+}
+
+func (h *handler) GetJob(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.InvalidParameter("id"))
+		return
+	}
+
+	result, err := h.usecase.GetJob(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -6,40 +6,112 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/takanoakira/ai-interview-practice/backend/internal/apperror"
 	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/handler/request"
 	"github.com/takanoakira/ai-interview-practice/backend/internal/usecase/job_posting"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/usecase/job_posting_parse"
 )
 
 type Handler interface {
+	GetJobPosting(c *gin.Context)
+	ListJobPostingsByCompany(c *gin.Context)
 	CreateJobPosting(c *gin.Context)
 	UpdateJobPosting(c *gin.Context)
+	PatchJobPosting(c *gin.Context)
 	DeleteJobPosting(c *gin.Context)
+	RestoreJobPosting(c *gin.Context)
+	MoveJobPosting(c *gin.Context)
+	ListJobPostingMoves(c *gin.Context)
+	ParseJobPosting(c *gin.Context)
 }
 
 type handler struct {
-	usecase job_posting.UseCase
+	usecase      job_posting.UseCase
+	parseUsecase job_posting_parse.UseCase
 }
 
-func NewHandler(usecase job_posting.UseCase) Handler {
-	return &handler{usecase: usecase}
+func NewHandler(usecase job_posting.UseCase, parseUsecase job_posting_parse.UseCase) Handler {
+	return &handler{usecase: usecase, parseUsecase: parseUsecase}
 }
 
 type CreateJobPostingRequest struct {
 	CompanyID    int                           `json:"company_id" binding:"required"`
 	Title        string                        `json:"title" binding:"required,max=100"`
 	Description  *string                       `json:"description,omitempty" binding:"omitempty,max=1000"`
-	CustomFields []CreateJobCustomFieldRequest `json:"custom_fields,omitempty"`
+	CustomFields []CreateJobCustomFieldRequest `json:"custom_fields,omitempty" binding:"omitempty,max=20,dive"`
 }
 
-type CreateJobCustomFieldRequest struct {
+// CreateJobCustomFieldRequest は求人情報の追加情報の作成リクエストです（規則はインポートと共通）
+type CreateJobCustomFieldRequest = request.CustomField
+
+// UpdateJobPostingRequest は求人情報の更新（PUT）リクエストです。
+// 追加情報は送信した内容に置き換わります。既存の追加情報を残す場合は id を指定します（id のない項目は追加されます）。
+type UpdateJobPostingRequest struct {
+	CompanyID    int                           `json:"company_id" binding:"required"`
+	Title        string                        `json:"title" binding:"required,max=100"`
+	Description  *string                       `json:"description,omitempty" binding:"omitempty,max=1000"`
+	CustomFields []UpdateJobCustomFieldRequest `json:"custom_fields,omitempty" binding:"omitempty,max=20,dive"`
+}
+
+type UpdateJobCustomFieldRequest struct {
+	ID        int    `json:"id,omitempty" binding:"omitempty,min=1"`
 	FieldName string `json:"field_name" binding:"required,max=50"`
 	Content   string `json:"content" binding:"required,max=500"`
 }
 
+// MoveJobPostingRequest は求人情報を別の企業に移動するリクエストです
+type MoveJobPostingRequest struct {
+	CompanyID int     `json:"company_id" binding:"required"`
+	Reason    *string `json:"reason,omitempty" binding:"omitempty,max=500"`
+}
+
+func (h *handler) GetJobPosting(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.InvalidParameter("id"))
+		return
+	}
+
+	result, err := h.usecase.GetJobPosting(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if request.NotModified(c, result.Version) {
+		return
+	}
+
+	request.SetETag(c, result.Version)
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *handler) ListJobPostingsByCompany(c *gin.Context) {
+	companyID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.InvalidParameter("id"))
+		return
+	}
+
+	page, limit, err := request.Pagination(c, 10)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	result, err := h.usecase.ListJobPostingsByCompany(c.Request.Context(), companyID, page, limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
 func (h *handler) CreateJobPosting(c *gin.Context) {
 	var req CreateJobPostingRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(apperror.FromBinding(err))
 		return
 	}
 
@@ -58,23 +130,35 @@ func (h *handler) CreateJobPosting(c *gin.Context) {
 
 	result, err := h.usecase.CreateJobPosting(c.Request.Context(), jobPosting)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
+	request.SetETag(c, result.Version)
 	c.JSON(http.StatusCreated, result)
 }
 
 func (h *handler) UpdateJobPosting(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id parameter"})
+		c.Error(apperror.InvalidParameter("id"))
 		return
 	}
 
-	var req CreateJobPostingRequest
+	var req UpdateJobPostingRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	current, err := h.usecase.GetJobPosting(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	version, err := request.IfMatch(c, current.Version)
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -83,10 +167,12 @@ func (h *handler) UpdateJobPosting(c *gin.Context) {
 		CompanyID:   req.CompanyID,
 		Title:       req.Title,
 		Description: req.Description,
+		Version:     version,
 	}
 
 	for _, field := range req.CustomFields {
 		jobPosting.CustomFields = append(jobPosting.CustomFields, entity.JobCustomField{
+			ID:        field.ID,
 			FieldName: field.FieldName,
 			Content:   field.Content,
 		})
@@ -94,24 +180,134 @@ func (h *handler) UpdateJobPosting(c *gin.Context) {
 
 	result, err := h.usecase.UpdateJobPosting(c.Request.Context(), jobPosting)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
+	request.SetETag(c, result.Version)
+	c.JSON(http.StatusOK, result)
+}
+
+// PatchJobPosting は merge patch（RFC 7396）で求人情報を部分的に更新します
+func (h *handler) PatchJobPosting(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.InvalidParameter("id"))
+		return
+	}
+
+	current, err := h.usecase.GetJobPosting(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	version, err := request.IfMatch(c, current.Version)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var document JobPostingPatchDocument
+	if err := request.BindMergePatch(c, newJobPostingPatchDocument(current), &document); err != nil {
+		c.Error(err)
+		return
+	}
+
+	// patch は取得時点の内容に適用するため、If-Match がない場合も取得時点のバージョンで更新する
+	if version == 0 {
+		version = current.Version
+	}
+	jobPosting := document.toEntity(id)
+	jobPosting.Version = version
+	result, err := h.usecase.UpdateJobPosting(c.Request.Context(), jobPosting)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	request.SetETag(c, result.Version)
 	c.JSON(http.StatusOK, result)
 }
 
 func (h *handler) DeleteJobPosting(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id parameter"})
+		c.Error(apperror.InvalidParameter("id"))
 		return
 	}
 
-	if err := h.usecase.DeleteJobPosting(c.Request.Context(), id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	current, err := h.usecase.GetJobPosting(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	version, err := request.IfMatch(c, current.Version)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.usecase.DeleteJobPosting(c.Request.Context(), id, version); err != nil {
+		c.Error(err)
 		return
 	}
 
 	c.Status(http.StatusNoContent)
 }
+
+// RestoreJobPosting はゴミ箱にある求人情報を元に戻します
+func (h *handler) RestoreJobPosting(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.InvalidParameter("id"))
+		return
+	}
+
+	result, err := h.usecase.RestoreJobPosting(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	request.SetETag(c, result.Version)
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *handler) MoveJobPosting(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.InvalidParameter("id"))
+		return
+	}
+
+	var req MoveJobPostingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	result, err := h.usecase.MoveJobPosting(c.Request.Context(), id, req.CompanyID, req.Reason)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	request.SetETag(c, result.Version)
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *handler) ListJobPostingMoves(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.InvalidParameter("id"))
+		return
+	}
+
+	result, err := h.usecase.ListJobPostingMoves(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"moves": result})
+}
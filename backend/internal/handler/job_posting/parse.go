@@ -0,0 +1,211 @@
+package job_posting
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/apperror"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/handler/request"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/htmldoc"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/jobparse"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/usecase/job_posting_parse"
+)
+
+// maxParseSize は求人票の解析リクエストのボディの最大サイズです
+const maxParseSize = 1 << 20
+
+// ParseJobPostingRequest は求人票の解析リクエストです。text（貼り付けたテキスト）と html のどちらか一方を指定します。
+type ParseJobPostingRequest struct {
+	Text *string `json:"text,omitempty"`
+	HTML *string `json:"html,omitempty"`
+	// CompanyID は下書きに設定する企業です
+	CompanyID int  `json:"company_id,omitempty" binding:"omitempty,min=1"`
+	UseLLM    bool `json:"use_llm,omitempty"`
+}
+
+// ParseJobPostingResponse は求人票の解析結果です。draft はそのまま（または編集して）求人情報の作成リクエストに使用できます。
+type ParseJobPostingResponse struct {
+	Draft       CreateJobPostingRequest    `json:"draft"`
+	Extracted   entity.ExtractedJobPosting `json:"extracted"`
+	LLMAssisted bool                       `json:"llm_assisted"`
+	Warnings    []string                   `json:"warnings"`
+}
+
+// errUnsupportedParseType は解析に対応していない形式のリクエストの場合に返されます
+var errUnsupportedParseType = apperror.New(apperror.CodeUnsupportedMediaType,
+	"content type must be application/json or multipart/form-data")
+
+// ParseJobPosting は求人票のテキスト・HTML から項目を抽出し、求人情報の作成リクエストの下書きを返します。
+// JSON（text または html）または multipart/form-data の file（HTML・テキストのファイル）で受け付けます。
+func (h *handler) ParseJobPosting(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxParseSize)
+	mediaType, _, err := mime.ParseMediaType(c.ContentType())
+	if err != nil {
+		c.Error(errUnsupportedParseType)
+		return
+	}
+
+	var req ParseJobPostingRequest
+	switch mediaType {
+	case "application/json":
+		err = request.BindStrictJSON(c, &req)
+	case "multipart/form-data":
+		err = bindParseForm(c, &req)
+	default:
+		err = errUnsupportedParseType
+	}
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			err = apperror.PayloadTooLarge("job posting is too large (max 1MB)").Wrap(err)
+		}
+		c.Error(err)
+		return
+	}
+
+	input, err := req.toInput()
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	result, err := h.parseUsecase.Parse(c.Request.Context(), input)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ParseJobPostingResponse{
+		Draft:       draftJobPosting(req.CompanyID, &result.Extracted),
+		Extracted:   result.Extracted,
+		LLMAssisted: result.LLMAssisted,
+		Warnings:    result.Warnings,
+	})
+}
+
+// bindParseForm は multipart/form-data の file・company_id・use_llm を読み込みます。
+// ファイルは拡張子・内容が HTML の場合は HTML、それ以外は UTF-8 のテキストとして扱います。
+func bindParseForm(c *gin.Context, req *ParseJobPostingRequest) error {
+	file, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return err
+		}
+		return apperror.Validation("file is required", apperror.Detail{Field: "file", Message: "is required"})
+	}
+
+	var details []apperror.Detail
+	if raw := c.PostForm("company_id"); raw != "" {
+		id, err := strconv.Atoi(raw)
+		if err != nil || id < 1 {
+			details = append(details, apperror.Detail{Field: "company_id", Message: "must be a positive integer"})
+		}
+		req.CompanyID = id
+	}
+	if raw := c.PostForm("use_llm"); raw != "" {
+		useLLM, err := strconv.ParseBool(raw)
+		if err != nil {
+			details = append(details, apperror.Detail{Field: "use_llm", Message: "must be true or false"})
+		}
+		req.UseLLM = useLLM
+	}
+	if len(details) > 0 {
+		return apperror.Validation("invalid form values", details...)
+	}
+
+	f, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return err
+	}
+	content := string(bytes.TrimPrefix(data, []byte("\ufeff")))
+	switch strings.ToLower(filepath.Ext(file.Filename)) {
+	case ".html", ".htm":
+		req.HTML = &content
+		return nil
+	}
+	if strings.HasPrefix(http.DetectContentType(data), "text/html") {
+		req.HTML = &content
+		return nil
+	}
+	if !utf8.ValidString(content) {
+		return apperror.Validation("file must be HTML or UTF-8 text", apperror.Detail{Field: "file", Message: "must be HTML or UTF-8 text"})
+	}
+	req.Text = &content
+	return nil
+}
+
+// toInput は text・html のどちらか一方を解析の入力に変換します。HTML は本文のテキストと見出しを取り出します。
+func (req *ParseJobPostingRequest) toInput() (job_posting_parse.Input, error) {
+	input := job_posting_parse.Input{UseLLM: req.UseLLM}
+	switch {
+	case req.Text != nil && req.HTML != nil:
+		return input, apperror.Validation("specify either text or html",
+			apperror.Detail{Field: "html", Message: "cannot be combined with text"})
+	case req.Text != nil:
+		input.Text = *req.Text
+	case req.HTML != nil:
+		doc, err := htmldoc.Parse(strings.NewReader(*req.HTML))
+		if err != nil {
+			return input, apperror.Validation("invalid html", apperror.Detail{Field: "html", Message: "could not be parsed"})
+		}
+		input.Text, input.TitleHint = doc.Text, doc.Title
+	default:
+		return input, apperror.Validation("text or html is required",
+			apperror.Detail{Field: "text", Message: "is required unless html is specified"})
+	}
+	return input, nil
+}
+
+// draftJobPosting は抽出した項目から求人情報の作成リクエストの下書きを作成します。
+// 給与・勤務地などは追加情報とし、作成リクエストの文字数の制限に収まるよう切り詰めます。
+func draftJobPosting(companyID int, extracted *entity.ExtractedJobPosting) CreateJobPostingRequest {
+	draft := CreateJobPostingRequest{
+		CompanyID: companyID,
+		Title:     htmldoc.Truncate(extracted.Title, 100),
+	}
+	if extracted.Description != "" {
+		description := htmldoc.Truncate(extracted.Description, 1000)
+		draft.Description = &description
+	}
+
+	add := func(name, content string) {
+		if content = strings.TrimSpace(content); content != "" {
+			draft.CustomFields = append(draft.CustomFields, CreateJobCustomFieldRequest{
+				FieldName: htmldoc.Truncate(name, 50),
+				Content:   htmldoc.Truncate(content, 500),
+			})
+		}
+	}
+	add(entity.JobFieldEmploymentType, extracted.EmploymentType)
+	if extracted.Salary != nil {
+		add(entity.JobFieldSalary, jobparse.FormatSalary(extracted.Salary))
+	}
+	add(entity.JobFieldLocation, extracted.Location)
+	add(entity.JobFieldRequiredSkills, bulletList(extracted.RequiredSkills))
+	add(entity.JobFieldBenefits, bulletList(extracted.Benefits))
+	return draft
+}
+
+// bulletList は項目を「・」で始まる行にまとめます
+func bulletList(items []string) string {
+	lines := make([]string, len(items))
+	for i, item := range items {
+		lines[i] = "・" + item
+	}
+	return strings.Join(lines, "\n")
+}
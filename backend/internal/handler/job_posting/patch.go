@@ -0,0 +1,54 @@
+package job_posting
+
+import (
+	"strconv"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/handler/request"
+)
+
+// JobPostingPatchDocument は PATCH で merge patch を適用する求人情報の表現です。
+// 追加情報は ID をキーとするオブジェクトで表し、キーごとに更新（オブジェクト）・削除（null）できます。
+// 数値でないキー（"new" など）の項目は追加されます。company_id は変更できません（move を使用します）。
+type JobPostingPatchDocument struct {
+	CompanyID    int                                    `json:"company_id" binding:"required"`
+	Title        string                                 `json:"title" binding:"required,max=100"`
+	Description  *string                                `json:"description,omitempty" binding:"omitempty,max=1000"`
+	CustomFields map[string]CreateJobCustomFieldRequest `json:"custom_fields,omitempty" binding:"omitempty,max=20,dive"`
+}
+
+// newJobPostingPatchDocument は現在の求人情報から merge patch の適用対象を作成します
+func newJobPostingPatchDocument(jobPosting *entity.JobPosting) *JobPostingPatchDocument {
+	document := &JobPostingPatchDocument{
+		CompanyID:    jobPosting.CompanyID,
+		Title:        jobPosting.Title,
+		Description:  jobPosting.Description,
+		CustomFields: make(map[string]CreateJobCustomFieldRequest, len(jobPosting.CustomFields)),
+	}
+	for _, field := range jobPosting.CustomFields {
+		document.CustomFields[strconv.Itoa(field.ID)] = CreateJobCustomFieldRequest{
+			FieldName: field.FieldName,
+			Content:   field.Content,
+		}
+	}
+	return document
+}
+
+// toEntity は適用後のドキュメントを ID の求人情報エンティティに変換します
+func (d *JobPostingPatchDocument) toEntity(id int) *entity.JobPosting {
+	jobPosting := &entity.JobPosting{
+		ID:          id,
+		CompanyID:   d.CompanyID,
+		Title:       d.Title,
+		Description: d.Description,
+	}
+	for _, key := range request.SortedPatchKeys(d.CustomFields) {
+		field := d.CustomFields[key]
+		jobPosting.CustomFields = append(jobPosting.CustomFields, entity.JobCustomField{
+			ID:        request.PatchKeyID(key),
+			FieldName: field.FieldName,
+			Content:   field.Content,
+		})
+	}
+	return jobPosting
+}
@@ -0,0 +1,112 @@
+package organization
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/apperror"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/usecase/organization"
+)
+
+type Handler interface {
+	CreateOrganization(c *gin.Context)
+	ListOrganizations(c *gin.Context)
+	ListMembers(c *gin.Context)
+	CreateInvitation(c *gin.Context)
+	AcceptInvitation(c *gin.Context)
+}
+
+type handler struct {
+	usecase organization.UseCase
+}
+
+func NewHandler(usecase organization.UseCase) Handler {
+	return &handler{usecase: usecase}
+}
+
+type CreateOrganizationRequest struct {
+	Name string `json:"name" binding:"required,max=100"`
+}
+
+type CreateInvitationRequest struct {
+	Email *string               `json:"email,omitempty" binding:"omitempty,email,max=255"`
+	Role  entity.MembershipRole `json:"role" binding:"required,oneof=admin coach candidate"`
+}
+
+func (h *handler) CreateOrganization(c *gin.Context) {
+	var req CreateOrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	result, err := h.usecase.CreateOrganization(c.Request.Context(), req.Name)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+func (h *handler) ListOrganizations(c *gin.Context) {
+	result, err := h.usecase.ListOrganizations(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"memberships": result})
+}
+
+func (h *handler) ListMembers(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.InvalidParameter("id"))
+		return
+	}
+
+	result, err := h.usecase.ListMembers(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"members": result})
+}
+
+func (h *handler) CreateInvitation(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.InvalidParameter("id"))
+		return
+	}
+
+	var req CreateInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	result, err := h.usecase.CreateInvitation(c.Request.Context(), id, req.Email, req.Role)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusCreated, result)
+}
+
+func (h *handler) AcceptInvitation(c *gin.Context) {
+	result, err := h.usecase.AcceptInvitation(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,8 @@
+package request
+
+// CustomField は企業・求人情報の追加情報の作成リクエストの項目です。
+// 企業・求人情報の作成・部分更新・インポートで共通の規則を使用するため、各リクエストはこの型を使用します。
+type CustomField struct {
+	FieldName string `json:"field_name" binding:"required,max=50"`
+	Content   string `json:"content" binding:"required,max=500"`
+}
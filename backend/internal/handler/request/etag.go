@@ -0,0 +1,59 @@
+package request
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/repository"
+)
+
+// ETag はリソースのバージョンから ETag ヘッダーの値を作成します
+func ETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// SetETag はレスポンスに ETag ヘッダーを設定します
+func SetETag(c *gin.Context, version int) {
+	c.Header("ETag", ETag(version))
+}
+
+// IfMatch は If-Match ヘッダーを現在のバージョンと比較し、更新・削除時にリポジトリで確認するバージョンを返します。
+// ヘッダーがない場合と "*" の場合は 0（確認しない）を返します。
+// 一致しない場合は ErrVersionMismatch を返します。弱い ETag（W/）は一致しないものとして扱います。
+func IfMatch(c *gin.Context, current int) (int, error) {
+	header := c.GetHeader("If-Match")
+	if header == "" {
+		return 0, nil
+	}
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			return 0, nil
+		}
+		if tag == ETag(current) {
+			return current, nil
+		}
+	}
+	return 0, repository.ErrVersionMismatch
+}
+
+// NotModified は If-None-Match ヘッダーが現在のバージョンに一致する場合に 304 を返し、true を返します。
+// 比較は弱い比較（W/ を無視）で行います。
+func NotModified(c *gin.Context, current int) bool {
+	header := c.GetHeader("If-None-Match")
+	if header == "" {
+		return false
+	}
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || tag == ETag(current) {
+			SetETag(c, current)
+			c.Status(http.StatusNotModified)
+			return true
+		}
+	}
+	return false
+}
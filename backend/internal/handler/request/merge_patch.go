@@ -0,0 +1,123 @@
+package request
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/apperror"
+)
+
+// MergePatchContentType は JSON Merge Patch（RFC 7396）のメディアタイプです
+const MergePatchContentType = "application/merge-patch+json"
+
+// errUnsupportedPatchType は merge patch 以外の形式で PATCH が送られた場合に返されます
+var errUnsupportedPatchType = apperror.New(apperror.CodeUnsupportedMediaType,
+	"content type must be "+MergePatchContentType+" or application/json")
+
+// BindMergePatch はリクエストボディの merge patch を current の JSON 表現に適用し、結果を obj に読み込んで binding タグで検証します。
+// 値が null のキーは削除、オブジェクトは再帰的に適用、それ以外の値は置き換えになります。
+// 適用後のドキュメントに obj で定義されていないフィールドが含まれる場合はエラーになります。
+func BindMergePatch(c *gin.Context, current, obj interface{}) error {
+	if contentType := c.ContentType(); contentType != "" {
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err != nil || (mediaType != MergePatchContentType && mediaType != "application/json") {
+			return errUnsupportedPatchType
+		}
+	}
+	if c.Request.Body == nil || c.Request.Body == http.NoBody {
+		return apperror.BadRequest("request body is empty")
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return apperror.FromBinding(err)
+	}
+	var patch interface{}
+	if err := json.Unmarshal(body, &patch); err != nil {
+		return apperror.FromBinding(err)
+	}
+	if _, ok := patch.(map[string]interface{}); !ok {
+		// ドキュメント全体を置き換える patch は PUT を使用する
+		return apperror.Validation("merge patch must be a JSON object")
+	}
+
+	target, err := toDocument(current)
+	if err != nil {
+		return err
+	}
+	merged, err := json.Marshal(mergePatch(target, patch))
+	if err != nil {
+		return err
+	}
+	return DecodeStrictJSON(bytes.NewReader(merged), obj)
+}
+
+// toDocument は v を JSON の汎用表現（map・slice・プリミティブ）に変換します
+func toDocument(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var document interface{}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+	return document, nil
+}
+
+// mergePatch は RFC 7396 の MergePatch 関数です
+func mergePatch(target, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = map[string]interface{}{}
+	}
+	for name, value := range patchObject {
+		if value == nil {
+			delete(targetObject, name)
+			continue
+		}
+		targetObject[name] = mergePatch(targetObject[name], value)
+	}
+	return targetObject
+}
+
+// PatchKeyID は ID をキーとするオブジェクト（追加情報など）のキーから ID を取り出します。
+// 正の整数でないキーはクライアントが付けた新規の項目のキーとして扱い、0 を返します。
+func PatchKeyID(key string) int {
+	id, err := strconv.Atoi(key)
+	if err != nil || id < 1 {
+		return 0
+	}
+	return id
+}
+
+// SortedPatchKeys は既存の項目のキーを ID の昇順に、続けて新規の項目のキーを文字列の昇順に並べて返します
+func SortedPatchKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := PatchKeyID(keys[i]), PatchKeyID(keys[j])
+		switch {
+		case a != 0 && b != 0:
+			return a < b
+		case a != 0 || b != 0:
+			return a != 0
+		default:
+			return keys[i] < keys[j]
+		}
+	})
+	return keys
+}
@@ -0,0 +1,73 @@
+// Package request はハンドラー間で共通のリクエストの解析処理を提供します
+package request
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/apperror"
+)
+
+// maxLimit は一覧取得で1ページに返す件数の上限です
+const maxLimit = 100
+
+// BindStrictJSON はリクエストボディを obj に読み込み、binding タグで検証します。
+// ShouldBindJSON と異なり、obj に定義されていないフィールドを含む場合はエラーになります。
+// 返されるエラーは apperror の検証エラーです。
+func BindStrictJSON(c *gin.Context, obj interface{}) error {
+	if c.Request.Body == nil {
+		return apperror.BadRequest("request body is empty")
+	}
+	return DecodeStrictJSON(c.Request.Body, obj)
+}
+
+// DecodeStrictJSON は r の JSON を obj に読み込み、BindStrictJSON と同じ規則で検証します
+func DecodeStrictJSON(r io.Reader, obj interface{}) error {
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(obj); err != nil {
+		return apperror.FromBinding(err)
+	}
+	if err := binding.Validator.ValidateStruct(obj); err != nil {
+		return apperror.FromBinding(err)
+	}
+	return nil
+}
+
+// Pagination は page・limit クエリパラメータを解析します。
+// 指定されていない場合は 1・defaultLimit を使用し、limit は上限（100）に丸めます。
+// 正の整数でない場合は検証エラーを返します。
+func Pagination(c *gin.Context, defaultLimit int) (page, limit int, err error) {
+	var details []apperror.Detail
+	page, ok := positiveInt(c, "page", 1)
+	if !ok {
+		details = append(details, apperror.Detail{Field: "page", Message: "must be a positive integer"})
+	}
+	limit, ok = positiveInt(c, "limit", defaultLimit)
+	if !ok {
+		details = append(details, apperror.Detail{Field: "limit", Message: "must be a positive integer"})
+	}
+	if len(details) > 0 {
+		return 0, 0, apperror.Validation("invalid pagination parameters", details...)
+	}
+	if limit > maxLimit {
+		limit = maxLimit
+	}
+	return page, limit, nil
+}
+
+func positiveInt(c *gin.Context, name string, defaultValue int) (int, bool) {
+	raw, ok := c.GetQuery(name)
+	if !ok {
+		return defaultValue, true
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil || value < 1 {
+		return 0, false
+	}
+	return value, true
+}
@@ -0,0 +1,101 @@
+package speech
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/apperror"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/speech"
+	speechUsecase "github.com/takanoakira/ai-interview-practice/backend/internal/usecase/speech"
+)
+
+type Handler interface {
+	SpeechToText(c *gin.Context)
+	TextToSpeech(c *gin.Context)
+}
+
+type handler struct {
+	usecase speechUsecase.UseCase
+}
+
+func NewHandler(usecase speechUsecase.UseCase) Handler {
+	return &handler{usecase: usecase}
+}
+
+// maxAudioDataLength は base64 エンコード後の音声データの上限です
+var maxAudioDataLength = base64.StdEncoding.EncodedLen(speech.MaxAudioBytes)
+
+type SpeechToTextRequest struct {
+	AudioData string `json:"audio_data" binding:"required"`
+	Language  string `json:"language,omitempty" binding:"omitempty,max=10"`
+}
+
+type SpeechToTextResponse struct {
+	Text       string  `json:"text"`
+	Confidence float64 `json:"confidence"`
+}
+
+type TextToSpeechRequest struct {
+	Text  string `json:"text" binding:"required,max=1000"`
+	Voice string `json:"voice,omitempty" binding:"omitempty,max=50"`
+}
+
+type TextToSpeechResponse struct {
+	AudioData string `json:"audio_data"`
+	MimeType  string `json:"mime_type"`
+}
+
+func (h *handler) SpeechToText(c *gin.Context) {
+	// 音声データは JSON の解析前にサイズを制限する
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, int64(maxAudioDataLength)+1024)
+
+	var req SpeechToTextRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			c.Error(speech.ErrAudioTooLarge)
+			return
+		}
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	data, err := base64.StdEncoding.DecodeString(req.AudioData)
+	if err != nil {
+		c.Error(apperror.Validation("audio_data must be base64 encoded",
+			apperror.Detail{Field: "audio_data", Message: "must be base64 encoded"}))
+		return
+	}
+
+	// 音声データは変換にのみ使用し、保存・ログ出力は行わない
+	result, err := h.usecase.Transcribe(c.Request.Context(), speech.Audio{Data: data, Language: req.Language})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SpeechToTextResponse{Text: result.Text, Confidence: result.Confidence})
+}
+
+func (h *handler) TextToSpeech(c *gin.Context) {
+	var req TextToSpeechRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperror.FromBinding(err))
+		return
+	}
+
+	result, err := h.usecase.Synthesize(c.Request.Context(), speech.SynthesisRequest{Text: req.Text, Voice: req.Voice})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, TextToSpeechResponse{
+		AudioData: base64.StdEncoding.EncodeToString(result.Data),
+		MimeType:  result.MimeType,
+	})
+}
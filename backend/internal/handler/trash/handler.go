@@ -0,0 +1,31 @@
+package trash
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/usecase/trash"
+)
+
+type Handler interface {
+	ListTrash(c *gin.Context)
+}
+
+type handler struct {
+	usecase trash.UseCase
+}
+
+func NewHandler(usecase trash.UseCase) Handler {
+	return &handler{usecase: usecase}
+}
+
+func (h *handler) ListTrash(c *gin.Context) {
+	result, err := h.usecase.ListTrash(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
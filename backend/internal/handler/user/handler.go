@@ -0,0 +1,31 @@
+package user
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/usecase/user"
+)
+
+type Handler interface {
+	GetCurrentUser(c *gin.Context)
+}
+
+type handler struct {
+	usecase user.UseCase
+}
+
+func NewHandler(usecase user.UseCase) Handler {
+	return &handler{usecase: usecase}
+}
+
+func (h *handler) GetCurrentUser(c *gin.Context) {
+	result, err := h.usecase.GetCurrentUser(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
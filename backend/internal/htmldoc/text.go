@@ -0,0 +1,179 @@
+// Package htmldoc は HTML の文書から本文のテキストなどを取り出します
+package htmldoc
+
+import (
+	"io"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// Document は HTML から取り出した内容です
+type Document struct {
+	// Title は最初の h1 の見出し、なければ title 要素のテキストです
+	Title string
+	// Text は本文のテキストです。ブロック要素・br ごとに改行し、script・style などの内容は含みません。
+	Text string
+	// JSONLD は script type="application/ld+json" の内容（構造化データの JSON）です
+	JSONLD []string
+}
+
+// skipped は内容をテキストに含めない要素です
+var skipped = map[atom.Atom]bool{
+	atom.Script: true, atom.Style: true, atom.Noscript: true, atom.Template: true,
+	atom.Head: true, atom.Svg: true, atom.Iframe: true, atom.Nav: true, atom.Footer: true,
+}
+
+// blocks は前後で改行する要素です
+var blocks = map[atom.Atom]bool{
+	atom.P: true, atom.Div: true, atom.Section: true, atom.Article: true, atom.Main: true, atom.Header: true,
+	atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true, atom.H5: true, atom.H6: true,
+	atom.Ul: true, atom.Ol: true, atom.Li: true, atom.Dl: true,
+	atom.Table: true, atom.Tr: true, atom.Pre: true, atom.Blockquote: true,
+	atom.Hr: true, atom.Form: true, atom.Address: true, atom.Figure: true, atom.Figcaption: true,
+}
+
+var (
+	spaces     = regexp.MustCompile(`[ \t\f\v\x{00a0}\x{3000}]+`)
+	blankLines = regexp.MustCompile(`\n{3,}`)
+)
+
+// Parse は HTML を解析し、見出し・本文のテキストと JSON-LD の構造化データを返します
+func Parse(r io.Reader) (*Document, error) {
+	root, err := html.Parse(r)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &Document{}
+	var title string
+	var b strings.Builder
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		switch n.Type {
+		case html.TextNode:
+			b.WriteString(n.Data)
+			return
+		case html.ElementNode:
+			if n.DataAtom == atom.Title && title == "" {
+				title = textContent(n)
+			}
+			if skipped[n.DataAtom] {
+				return
+			}
+			if n.DataAtom == atom.H1 && doc.Title == "" {
+				doc.Title = textContent(n)
+			}
+			if n.DataAtom == atom.Br {
+				b.WriteString("\n")
+				return
+			}
+			// 表の行・定義リストの項目名と値は「項目名：値」の1行として読めるよう、セルの前に区切りを入れる
+			switch n.DataAtom {
+			case atom.Td, atom.Th, atom.Dd:
+				b.WriteString("：")
+			case atom.Dt:
+				b.WriteString("\n")
+			}
+		}
+		block := n.Type == html.ElementNode && blocks[n.DataAtom]
+		if block {
+			b.WriteString("\n")
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+		if block {
+			b.WriteString("\n")
+		}
+	}
+	walk(root)
+	// 構造化データは head などテキストに含めない要素の中にも置かれるため、文書全体から探す
+	doc.JSONLD = jsonLD(root, nil)
+
+	if doc.Title == "" {
+		doc.Title = title
+	}
+	doc.Title = normalizeSpace(doc.Title)
+	doc.Text = NormalizeText(b.String())
+	return doc, nil
+}
+
+// StripTags は HTML の断片からタグを取り除いたテキストを返します（説明文など、HTML を含むテキストの項目に使用します）
+func StripTags(fragment string) string {
+	if !strings.ContainsAny(fragment, "<&") {
+		return NormalizeText(fragment)
+	}
+	doc, err := Parse(strings.NewReader("<body>" + fragment + "</body>"))
+	if err != nil {
+		return NormalizeText(fragment)
+	}
+	return doc.Text
+}
+
+// NormalizeText は行ごとに連続する空白をまとめて前後の空白を取り除き、3行以上の空行を1行にします
+func NormalizeText(text string) string {
+	text = strings.ReplaceAll(strings.ReplaceAll(text, "\r\n", "\n"), "\r", "\n")
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		line = strings.TrimSpace(spaces.ReplaceAllString(line, " "))
+		// セルの区切りだけが残った行・行頭の区切りを取り除く
+		lines[i] = strings.TrimSpace(strings.TrimLeft(line, "："))
+	}
+	return strings.TrimSpace(blankLines.ReplaceAllString(strings.Join(lines, "\n"), "\n\n"))
+}
+
+// jsonLD は n 以下の JSON-LD の script の内容を文書の順に scripts に追加します
+// Truncate は s を最大 n 文字（rune）に切り詰めます。バイト単位で切らないため、マルチバイト文字が壊れません。
+func Truncate(s string, n int) string {
+	if n < 0 {
+		n = 0
+	}
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
+
+func jsonLD(n *html.Node, scripts []string) []string {
+	if n.Type == html.ElementNode && n.DataAtom == atom.Script && isJSONLD(n) {
+		return append(scripts, textContent(n))
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		scripts = jsonLD(c, scripts)
+	}
+	return scripts
+}
+
+// isJSONLD は script 要素が JSON-LD の構造化データかどうかを返します
+func isJSONLD(n *html.Node) bool {
+	for _, attr := range n.Attr {
+		if attr.Key == "type" {
+			mediaType, _, _ := strings.Cut(attr.Val, ";")
+			return strings.EqualFold(strings.TrimSpace(mediaType), "application/ld+json")
+		}
+	}
+	return false
+}
+
+func textContent(n *html.Node) string {
+	var b strings.Builder
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			b.WriteString(n.Data)
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+	return b.String()
+}
+
+func normalizeSpace(s string) string {
+	return strings.TrimSpace(spaces.ReplaceAllString(strings.ReplaceAll(s, "\n", " "), " "))
+}
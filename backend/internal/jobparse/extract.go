@@ -0,0 +1,274 @@
+// Package jobparse は求人票のテキストから求人の項目を規則に基づいて抽出します。
+// 「【応募資格】」「給与：」などの見出しで区切られた日本語・英語の求人票を想定しています。
+package jobparse
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/htmldoc"
+)
+
+// 抽出結果の各項目の最大文字数です（求人情報の作成リクエストの制限に合わせる）
+const (
+	maxTitleLength       = 100
+	maxDescriptionLength = 1000
+	maxValueLength       = 200
+	maxListItems         = 20
+)
+
+// field は見出しが表す項目です
+type field int
+
+const (
+	fieldNone field = iota
+	fieldTitle
+	fieldDescription
+	fieldEmploymentType
+	fieldSalary
+	fieldLocation
+	fieldSkills
+	fieldBenefits
+	// fieldOther は抽出しない項目（歓迎スキル・選考フローなど）の見出しです。直前の項目の終わりを表します。
+	fieldOther
+)
+
+// labels は見出しの項目名に含まれる語と項目の対応です。先に一致したものを使用します（「歓迎資格」を応募資格と区別するため歓迎を先に判定します）。
+var labels = []struct {
+	field field
+	words []string
+}{
+	{fieldOther, []string{"歓迎", "尚可", "選考", "応募方法", "会社概要", "企業情報", "nice to have", "preferred", "bonus points", "how to apply", "about us"}},
+	{fieldSalary, []string{"給与", "給料", "年収", "年俸", "月給", "報酬", "賃金", "salary", "compensation", "pay"}},
+	{fieldBenefits, []string{"福利厚生", "待遇", "benefits", "perks"}},
+	{fieldEmploymentType, []string{"雇用形態", "雇用区分", "契約形態", "employment type", "job type", "employment"}},
+	{fieldLocation, []string{"勤務地", "勤務場所", "就業場所", "勤務先", "location", "office"}},
+	{fieldSkills, []string{"必須", "応募資格", "応募条件", "応募要件", "求めるスキル", "必要なスキル", "必要な経験", "求める経験", "求める人物", "requirements", "qualifications", "required skills", "must have", "what you bring"}},
+	{fieldTitle, []string{"募集職種", "職種", "求人タイトル", "ポジション", "募集ポジション", "job title", "position", "title", "role"}},
+	{fieldDescription, []string{"仕事内容", "業務内容", "職務内容", "具体的な業務", "job description", "responsibilities", "description", "about the role", "what you'll do", "what you will do"}},
+}
+
+var (
+	// bracketHeading は【応募資格】・[Requirements] 形式の見出しです
+	bracketHeading = regexp.MustCompile(`^[【\[〔［≪《<＜]\s*([^】\]〕］≫》>＞]{1,30}?)\s*[】\]〕］≫》>＞]\s*[:：]?\s*(.*)$`)
+	// markHeading は ■応募資格・# Requirements 形式の見出しです
+	markHeading = regexp.MustCompile(`^(?:[■□◆◇●▼▽★☆▶▷]|#{1,6})\s*(.{1,30}?)\s*(?:[:：]\s*(.*))?$`)
+	// inlineHeading は「給与：年収500万円」形式の項目名と値です
+	inlineHeading = regexp.MustCompile(`^([^:：]{1,20}?)\s*[:：]\s*(.*)$`)
+	// bullet は箇条書きの記号・番号です
+	bullet = regexp.MustCompile(`^(?:[・\-\*•●○◎〇◯※✓✔■□▪◆◇▸►]|\d{1,2}[\.\)）]|[（(]\d{1,2}[)）])\s*`)
+)
+
+// heading は見出しの行を解析した結果です
+type heading struct {
+	field field
+	// value は見出しと同じ行に続く値です
+	value string
+	// inline は「項目名：値」形式の見出しです。値の後の空行で項目が終わります。
+	inline bool
+}
+
+// parseHeading は行が見出しであれば項目と値を返します。
+// 記号付きの見出しは項目名が不明でも fieldOther として扱い、「項目名：値」の形式は既知の項目名の場合のみ見出しとします。
+func parseHeading(line string) (heading, bool) {
+	if m := bracketHeading.FindStringSubmatch(line); m != nil {
+		return heading{field: classify(m[1], fieldOther), value: strings.TrimSpace(m[2])}, true
+	}
+	if m := markHeading.FindStringSubmatch(line); m != nil {
+		return heading{field: classify(m[1], fieldOther), value: strings.TrimSpace(m[2]), inline: m[2] != ""}, true
+	}
+	if m := inlineHeading.FindStringSubmatch(line); m != nil {
+		if f := classify(m[1], fieldNone); f != fieldNone {
+			value := strings.TrimSpace(m[2])
+			return heading{field: f, value: value, inline: value != ""}, true
+		}
+	}
+	if utf8.RuneCountInString(line) <= 20 {
+		if f := classify(line, fieldNone); f != fieldNone && f != fieldOther {
+			return heading{field: f}, true
+		}
+	}
+	return heading{}, false
+}
+
+// classify は項目名の表す項目を返します。該当しない場合は unknown を返します。
+func classify(label string, unknown field) field {
+	label = strings.ToLower(strings.TrimSpace(label))
+	for _, l := range labels {
+		for _, word := range l.words {
+			if strings.Contains(label, word) {
+				return l.field
+			}
+		}
+	}
+	return unknown
+}
+
+// Extract は求人票のテキストから項目を抽出します。titleHint は HTML の見出しなど、タイトルの候補です。
+func Extract(text, titleHint string) entity.ExtractedJobPosting {
+	sections := map[field][]string{}
+	var preamble []string
+	current, inline := fieldNone, false
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			if inline {
+				current, inline = fieldNone, false
+			}
+			continue
+		}
+		if h, ok := parseHeading(line); ok {
+			current, inline = h.field, h.inline
+			if h.value != "" {
+				sections[current] = append(sections[current], h.value)
+			}
+			continue
+		}
+		if current == fieldNone {
+			preamble = append(preamble, line)
+			continue
+		}
+		sections[current] = append(sections[current], line)
+	}
+
+	var result entity.ExtractedJobPosting
+
+	// タイトル: 見出し → HTML の見出し → 冒頭の短い行
+	switch {
+	case len(sections[fieldTitle]) > 0:
+		result.Title = sections[fieldTitle][0]
+	case titleHint != "":
+		result.Title = titleHint
+		if len(preamble) > 0 && preamble[0] == titleHint {
+			preamble = preamble[1:]
+		}
+	default:
+		if i := titleLine(preamble); i >= 0 {
+			result.Title = preamble[i]
+			preamble = append(preamble[:i:i], preamble[i+1:]...)
+		}
+	}
+	result.Title = htmldoc.Truncate(result.Title, maxTitleLength)
+
+	// 仕事内容: 見出し → 見出しの前の文章 → 全文
+	description := sections[fieldDescription]
+	if len(description) == 0 {
+		description = preamble
+	}
+	if len(description) == 0 {
+		description = strings.Split(text, "\n")
+	}
+	result.Description = htmldoc.Truncate(strings.TrimSpace(strings.Join(description, "\n")), maxDescriptionLength)
+
+	if lines := sections[fieldEmploymentType]; len(lines) > 0 {
+		result.EmploymentType = htmldoc.Truncate(lines[0], maxValueLength)
+	} else {
+		result.EmploymentType = findEmploymentType(text)
+	}
+
+	salarySource := text
+	if lines := sections[fieldSalary]; len(lines) > 0 {
+		salarySource = strings.Join(lines, "\n")
+	}
+	result.Salary = ParseSalary(salarySource)
+	if result.Salary == nil && len(sections[fieldSalary]) > 0 {
+		// 金額を読み取れない表記（「経験・能力を考慮の上決定」など）は表記のみを返す
+		result.Salary = &entity.SalaryRange{Text: htmldoc.Truncate(sections[fieldSalary][0], maxValueLength)}
+	}
+
+	if lines := sections[fieldLocation]; len(lines) > 0 {
+		result.Location = htmldoc.Truncate(strings.Join(lines, " / "), maxValueLength)
+	} else {
+		result.Location = findLocation(text)
+	}
+
+	result.RequiredSkills = listItems(sections[fieldSkills])
+	result.Benefits = listItems(sections[fieldBenefits])
+	return result
+}
+
+// companySuffixes は企業名の行を表す語です。冒頭の企業名をタイトルとみなさないために使用します。
+var companySuffixes = []string{"株式会社", "有限会社", "合同会社", "inc.", "co., ltd.", "corporation", "llc"}
+
+// titleLine は見出しの前の行からタイトルとみなす行の位置を返します。
+// 冒頭の3行のうち、企業名でも文章でもない短い行を選びます。ない場合は -1 を返します。
+func titleLine(lines []string) int {
+	for i, line := range lines {
+		if i == 3 {
+			break
+		}
+		if utf8.RuneCountInString(line) > 60 || strings.HasSuffix(line, "。") || strings.HasSuffix(line, ".") {
+			continue
+		}
+		lower := strings.ToLower(line)
+		isCompany := false
+		for _, suffix := range companySuffixes {
+			if strings.Contains(lower, suffix) {
+				isCompany = true
+				break
+			}
+		}
+		if !isCompany {
+			return i
+		}
+	}
+	return -1
+}
+
+// listItems は箇条書きの行を項目に分けます。箇条書きでない1行は「、」「,」「/」で区切ります。
+func listItems(lines []string) []string {
+	if len(lines) == 1 && !bullet.MatchString(lines[0]) {
+		lines = strings.FieldsFunc(lines[0], func(r rune) bool {
+			return r == '、' || r == ',' || r == '，' || r == '/' || r == '／'
+		})
+	}
+	var items []string
+	for _, line := range lines {
+		item := strings.TrimSpace(bullet.ReplaceAllString(strings.TrimSpace(line), ""))
+		if item == "" {
+			continue
+		}
+		items = append(items, htmldoc.Truncate(item, maxValueLength))
+		if len(items) == maxListItems {
+			break
+		}
+	}
+	return items
+}
+
+// employmentTypes は本文から探す雇用形態の語です
+var employmentTypes = []string{
+	"正社員", "契約社員", "派遣社員", "業務委託", "パート", "アルバイト", "インターン", "嘱託社員",
+	"full-time", "full time", "part-time", "part time", "contract", "internship", "temporary", "freelance",
+}
+
+// findEmploymentType は本文で最初に現れる雇用形態の語を employmentTypes の表記で返します。
+// 小文字に変換するとバイト長が変わる文字があるため、位置は小文字の本文の中でのみ比較し、元の本文を切り出しません。
+func findEmploymentType(text string) string {
+	lower := strings.ToLower(text)
+	found, at := "", -1
+	for _, word := range employmentTypes {
+		if i := strings.Index(lower, word); i >= 0 && (at < 0 || i < at) {
+			found, at = word, i
+		}
+	}
+	return found
+}
+
+// prefecture は都道府県で始まる所在地です
+var prefecture = regexp.MustCompile(`(?:北海道|東京都|(?:京都|大阪)府|(?:青森|岩手|宮城|秋田|山形|福島|茨城|栃木|群馬|埼玉|千葉|神奈川|新潟|富山|石川|福井|山梨|長野|岐阜|静岡|愛知|三重|滋賀|兵庫|奈良|和歌山|鳥取|島根|岡山|広島|山口|徳島|香川|愛媛|高知|福岡|佐賀|長崎|熊本|大分|宮崎|鹿児島|沖縄)県)[^\s、。,，)）]{0,30}`)
+
+// findLocation は本文で最初に現れる都道府県で始まる所在地を返します。ない場合はリモート勤務の表記を探します。
+func findLocation(text string) string {
+	if location := prefecture.FindString(text); location != "" {
+		return location
+	}
+	for _, word := range []string{"フルリモート", "完全リモート", "fully remote", "remote"} {
+		if strings.Contains(strings.ToLower(text), word) {
+			return word
+		}
+	}
+	return ""
+}
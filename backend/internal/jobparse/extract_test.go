@@ -0,0 +1,93 @@
+package jobparse_test
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/jobparse"
+)
+
+func TestExtract(t *testing.T) {
+	text := strings.Join([]string{
+		"バックエンドエンジニア",
+		"株式会社サンプル",
+		"",
+		"【仕事内容】",
+		"Go による API の設計・開発",
+		"",
+		"【応募資格】",
+		"・Go での開発経験 3 年以上",
+		"・MySQL の設計経験",
+		"",
+		"【歓迎スキル】",
+		"・Kubernetes の運用経験",
+		"",
+		"雇用形態：正社員",
+		"給与：年収500万円-800万円",
+		"勤務地：東京都渋谷区",
+		"",
+		"■福利厚生",
+		"・リモート勤務可",
+		"・書籍購入補助",
+	}, "\n")
+
+	got := jobparse.Extract(text, "")
+	if got.Title != "バックエンドエンジニア" {
+		t.Errorf("Title = %q", got.Title)
+	}
+	if got.Description != "Go による API の設計・開発" {
+		t.Errorf("Description = %q", got.Description)
+	}
+	if got.EmploymentType != "正社員" {
+		t.Errorf("EmploymentType = %q", got.EmploymentType)
+	}
+	if got.Salary == nil || got.Salary.Min == nil || *got.Salary.Min != 5000000 || got.Salary.Max == nil || *got.Salary.Max != 8000000 {
+		t.Errorf("Salary = %+v, want 5000000〜8000000", got.Salary)
+	}
+	if got.Location != "東京都渋谷区" {
+		t.Errorf("Location = %q", got.Location)
+	}
+	if strings.Join(got.RequiredSkills, "|") != "Go での開発経験 3 年以上|MySQL の設計経験" {
+		t.Errorf("RequiredSkills = %q (歓迎スキルを含めない)", got.RequiredSkills)
+	}
+	if strings.Join(got.Benefits, "|") != "リモート勤務可|書籍購入補助" {
+		t.Errorf("Benefits = %q", got.Benefits)
+	}
+}
+
+func TestExtractEmploymentTypeFromBody(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"english in mixed case", "We are hiring a Full-Time engineer.", "full-time"},
+		{"first occurrence wins", "契約社員（正社員登用あり）", "契約社員"},
+		// 小文字に変換するとバイト長が変わる文字の後でも位置がずれない
+		{"rune that grows when lowercased", strings.Repeat("Ⱥ", 12) + " part-time", "part-time"},
+		{"rune that shrinks when lowercased", strings.Repeat("İ", 12) + " part-time", "part-time"},
+		{"none", "エンジニア募集", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := jobparse.Extract(tt.text, "")
+			if got.EmploymentType != tt.want {
+				t.Errorf("EmploymentType = %q, want %q", got.EmploymentType, tt.want)
+			}
+			if !utf8.ValidString(got.Title) || !utf8.ValidString(got.Description) {
+				t.Errorf("Extract returned invalid UTF-8: title=%q description=%q", got.Title, got.Description)
+			}
+		})
+	}
+}
+
+func TestExtractTruncatesByRunes(t *testing.T) {
+	got := jobparse.Extract("給与："+strings.Repeat("あ", 300)+"\n", strings.Repeat("職", 150))
+	if n := utf8.RuneCountInString(got.Title); n != 100 {
+		t.Errorf("Title length = %d runes, want 100", n)
+	}
+	if !utf8.ValidString(got.Title) {
+		t.Errorf("Title is invalid UTF-8: %q", got.Title)
+	}
+}
@@ -0,0 +1,237 @@
+package jobparse
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
+)
+
+var (
+	// yenSalary は「年収500万円〜800万円」「年収500万円-800万円」「月給25万円以上」「時給1,200円」形式の金額です
+	yenSalary = regexp.MustCompile(`(?:(年収|年俸|月給|月収|月額|日給|時給)[^\d\n]{0,10}?)?(\d[\d,]*(?:\.\d+)?)\s*(万円|万|千円|円)?\s*(?:(~|-)\s*(?:(\d[\d,]*(?:\.\d+)?)\s*(万円|万|千円|円))?)?`)
+	// dollarSalary は「$120k - $150k per year」「$120-150k per year」形式の金額です
+	dollarSalary = regexp.MustCompile(`(?i)\$\s?(\d[\d,]*(?:\.\d+)?)\s*(k)?(?:\s*(?:~|-|to)\s*\$?\s?(\d[\d,]*(?:\.\d+)?)\s*(k)?)?(?:\s*(?:/|per\s+|an?\s+)(year|yr|annum|month|mo|hour|hr))?`)
+)
+
+// salaryUnits は給与の単位を表す語です
+var salaryUnits = map[string]entity.SalaryUnit{
+	"年収": entity.SalaryUnitYear, "年俸": entity.SalaryUnitYear,
+	"月給": entity.SalaryUnitMonth, "月収": entity.SalaryUnitMonth, "月額": entity.SalaryUnitMonth,
+	"日給": entity.SalaryUnitDay, "時給": entity.SalaryUnitHour,
+	"year": entity.SalaryUnitYear, "yr": entity.SalaryUnitYear, "annum": entity.SalaryUnitYear,
+	"month": entity.SalaryUnitMonth, "mo": entity.SalaryUnitMonth,
+	"hour": entity.SalaryUnitHour, "hr": entity.SalaryUnitHour,
+}
+
+// normalizeDigits は全角の数字・記号と範囲を表す記号を半角に揃えます。
+// 空白のない半角の "-"（「$120-150k」）は日付などと区別するため置き換えず、金額の正規表現で範囲として扱います。
+var normalizeDigits = strings.NewReplacer(
+	"０", "0", "１", "1", "２", "2", "３", "3", "４", "4",
+	"５", "5", "６", "6", "７", "7", "８", "8", "９", "9",
+	"，", ",", "．", ".", "＄", "$", "￥", "¥",
+	"〜", "~", "～", "~", "－", "~", "―", "~", "–", "~", "—", "~", " - ", " ~ ",
+)
+
+// ParseSalary はテキストで最初に現れる給与の金額を解析します。金額が見つからない場合は nil を返します。
+func ParseSalary(text string) *entity.SalaryRange {
+	text = normalizeDigits.Replace(text)
+	yen := findYenSalary(text)
+	dollar := findDollarSalary(text)
+	switch {
+	case yen == nil && dollar == nil:
+		return nil
+	case yen == nil:
+		return dollar.salary
+	case dollar == nil || yen.at < dollar.at:
+		return yen.salary
+	default:
+		return dollar.salary
+	}
+}
+
+// salaryMatch は給与の金額と、テキスト中の位置です
+type salaryMatch struct {
+	salary *entity.SalaryRange
+	at     int
+}
+
+func findYenSalary(text string) *salaryMatch {
+	for _, m := range yenSalary.FindAllStringSubmatchIndex(text, -1) {
+		group := func(i int) string {
+			if m[2*i] < 0 {
+				return ""
+			}
+			return text[m[2*i]:m[2*i+1]]
+		}
+		minUnit, maxUnit := group(3), group(6)
+		// 金額の単位（円・万円）がないものは給与ではない数字とみなす
+		if minUnit == "" && maxUnit == "" {
+			continue
+		}
+		if minUnit == "" {
+			// 「500〜800万円」の下限は上限の単位を使用する
+			minUnit = maxUnit
+		}
+		salary := &entity.SalaryRange{
+			Currency: "JPY",
+			Unit:     salaryUnits[group(1)],
+			Text:     strings.TrimSpace(text[m[0]:m[1]]),
+		}
+		salary.Min = yenAmount(group(2), minUnit)
+		if group(5) != "" {
+			salary.Max = yenAmount(group(5), maxUnit)
+		}
+		// 上限を超える金額は給与ではない数字とみなす
+		if salary.Min == nil && salary.Max == nil {
+			continue
+		}
+		if salary.Unit == "" {
+			salary.Unit = guessUnit(salary.Min)
+		}
+		return &salaryMatch{salary: salary, at: m[0]}
+	}
+	return nil
+}
+
+func findDollarSalary(text string) *salaryMatch {
+	m := dollarSalary.FindStringSubmatchIndex(text)
+	if m == nil {
+		return nil
+	}
+	group := func(i int) string {
+		if m[2*i] < 0 {
+			return ""
+		}
+		return text[m[2*i]:m[2*i+1]]
+	}
+	multiplier := func(k string) float64 {
+		if k != "" {
+			return 1000
+		}
+		return 1
+	}
+	salary := &entity.SalaryRange{
+		Currency: "USD",
+		Unit:     salaryUnits[strings.ToLower(group(5))],
+		Text:     strings.TrimSpace(text[m[0]:m[1]]),
+	}
+	salary.Min = amount(group(1), multiplier(group(2)))
+	if group(3) != "" {
+		// 「$120-150k」の下限は上限の k を使用する
+		minK := group(2)
+		if minK == "" {
+			minK = group(4)
+			salary.Min = amount(group(1), multiplier(minK))
+		}
+		salary.Max = amount(group(3), multiplier(group(4)))
+	}
+	if salary.Min == nil && salary.Max == nil {
+		return nil
+	}
+	return &salaryMatch{salary: salary, at: m[0]}
+}
+
+// yenAmount は金額と単位（円・千円・万円）から円の金額を返します
+func yenAmount(number, unit string) *int {
+	switch {
+	case strings.HasPrefix(unit, "万"):
+		return amount(number, 10000)
+	case strings.HasPrefix(unit, "千"):
+		return amount(number, 1000)
+	default:
+		return amount(number, 1)
+	}
+}
+
+// maxAmount は金額として扱う上限です。これを超える数字は給与ではないものとして扱います（int への変換の桁あふれも防ぎます）。
+const maxAmount = math.MaxInt32
+
+// amount は数字に multiplier を掛けた金額を返します。数字でない場合と上限を超える場合は nil を返します。
+func amount(number string, multiplier float64) *int {
+	value, err := strconv.ParseFloat(strings.ReplaceAll(number, ",", ""), 64)
+	if err != nil {
+		return nil
+	}
+	value *= multiplier
+	if math.IsNaN(value) || value < 0 || value > maxAmount {
+		return nil
+	}
+	result := int(value)
+	return &result
+}
+
+// guessUnit は単位の表記がない円の金額から給与の単位を推測します
+func guessUnit(min *int) entity.SalaryUnit {
+	switch {
+	case min == nil:
+		return ""
+	case *min >= 1000000:
+		return entity.SalaryUnitYear
+	case *min >= 100000:
+		return entity.SalaryUnitMonth
+	case *min < 5000:
+		return entity.SalaryUnitHour
+	default:
+		return ""
+	}
+}
+
+// salaryUnitLabels は給与の単位の表記です
+var salaryUnitLabels = map[entity.SalaryUnit]string{
+	entity.SalaryUnitYear: "年収", entity.SalaryUnitMonth: "月給", entity.SalaryUnitWeek: "週給",
+	entity.SalaryUnitDay: "日給", entity.SalaryUnitHour: "時給",
+}
+
+// FormatSalary は給与の表記を返します。抽出元の表記がない場合は「年収 5,000,000〜8,000,000円」の形式で金額から組み立てます。
+func FormatSalary(salary *entity.SalaryRange) string {
+	if salary == nil {
+		return ""
+	}
+	if salary.Text != "" {
+		return salary.Text
+	}
+	if salary.Min == nil && salary.Max == nil {
+		return ""
+	}
+	var b strings.Builder
+	if label := salaryUnitLabels[salary.Unit]; label != "" {
+		b.WriteString(label + " ")
+	}
+	if salary.Min != nil {
+		b.WriteString(formatAmount(*salary.Min))
+	}
+	if salary.Max == nil || salary.Min == nil || *salary.Max != *salary.Min {
+		b.WriteString("〜")
+		if salary.Max != nil {
+			b.WriteString(formatAmount(*salary.Max))
+		}
+	}
+	switch salary.Currency {
+	case "JPY":
+		b.WriteString("円")
+	case "":
+	default:
+		b.WriteString(" " + salary.Currency)
+	}
+	return b.String()
+}
+
+// formatAmount は金額を3桁ごとにカンマで区切ります
+func formatAmount(n int) string {
+	digits := strconv.Itoa(n)
+	sign := ""
+	if n < 0 {
+		sign, digits = "-", digits[1:]
+	}
+	var b strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(d)
+	}
+	return sign + b.String()
+}
@@ -0,0 +1,130 @@
+package jobparse_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/jobparse"
+)
+
+func intPtr(n int) *int { return &n }
+
+func formatInt(n *int) string {
+	if n == nil {
+		return "nil"
+	}
+	return strconv.Itoa(*n)
+}
+
+func TestParseSalary(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want *entity.SalaryRange
+	}{
+		{
+			name: "yen range with wave dash",
+			text: "給与：年収500万円〜800万円",
+			want: &entity.SalaryRange{Min: intPtr(5000000), Max: intPtr(8000000), Currency: "JPY", Unit: entity.SalaryUnitYear},
+		},
+		{
+			name: "yen range with hyphen",
+			text: "年収500万円-800万円",
+			want: &entity.SalaryRange{Min: intPtr(5000000), Max: intPtr(8000000), Currency: "JPY", Unit: entity.SalaryUnitYear},
+		},
+		{
+			name: "yen range with unit only on max",
+			text: "500-800万円",
+			want: &entity.SalaryRange{Min: intPtr(5000000), Max: intPtr(8000000), Currency: "JPY", Unit: entity.SalaryUnitYear},
+		},
+		{
+			name: "full-width digits",
+			text: "月給２５万円～",
+			want: &entity.SalaryRange{Min: intPtr(250000), Currency: "JPY", Unit: entity.SalaryUnitMonth},
+		},
+		{
+			name: "hourly with comma",
+			text: "時給1,200円",
+			want: &entity.SalaryRange{Min: intPtr(1200), Currency: "JPY", Unit: entity.SalaryUnitHour},
+		},
+		{
+			name: "dollar range with k on max only",
+			text: "$120-150k per year",
+			want: &entity.SalaryRange{Min: intPtr(120000), Max: intPtr(150000), Currency: "USD", Unit: entity.SalaryUnitYear},
+		},
+		{
+			name: "dollar range with spaced separator",
+			text: "$120k - $150k/yr",
+			want: &entity.SalaryRange{Min: intPtr(120000), Max: intPtr(150000), Currency: "USD", Unit: entity.SalaryUnitYear},
+		},
+		{
+			name: "date before salary",
+			text: "2024-03-01 入社 月給25万円",
+			want: &entity.SalaryRange{Min: intPtr(250000), Currency: "JPY", Unit: entity.SalaryUnitMonth},
+		},
+		{
+			name: "date only",
+			text: "入社日：2024-03-01",
+			want: nil,
+		},
+		{
+			name: "out-of-range yen amount",
+			text: "9999999999999999999999万円",
+			want: nil,
+		},
+		{
+			name: "out-of-range dollar amount",
+			text: "$99999999999k",
+			want: nil,
+		},
+		{
+			name: "out-of-range max is dropped",
+			text: "年収300万円-99999999999万円",
+			want: &entity.SalaryRange{Min: intPtr(3000000), Currency: "JPY", Unit: entity.SalaryUnitYear},
+		},
+		{
+			name: "no amount",
+			text: "給与は経験に応じて決定",
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := jobparse.ParseSalary(tt.text)
+			if tt.want == nil {
+				if got != nil {
+					t.Fatalf("ParseSalary(%q) = %+v, want nil", tt.text, got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatalf("ParseSalary(%q) = nil, want %+v", tt.text, tt.want)
+			}
+			if formatInt(got.Min) != formatInt(tt.want.Min) || formatInt(got.Max) != formatInt(tt.want.Max) {
+				t.Errorf("ParseSalary(%q) min/max = %s/%s, want %s/%s", tt.text,
+					formatInt(got.Min), formatInt(got.Max), formatInt(tt.want.Min), formatInt(tt.want.Max))
+			}
+			if got.Currency != tt.want.Currency || got.Unit != tt.want.Unit {
+				t.Errorf("ParseSalary(%q) currency/unit = %s/%s, want %s/%s", tt.text, got.Currency, got.Unit, tt.want.Currency, tt.want.Unit)
+			}
+		})
+	}
+}
+
+func TestFormatSalary(t *testing.T) {
+	tests := []struct {
+		salary *entity.SalaryRange
+		want   string
+	}{
+		{nil, ""},
+		{&entity.SalaryRange{Text: "年収500万円〜"}, "年収500万円〜"},
+		{&entity.SalaryRange{Min: intPtr(5000000), Max: intPtr(8000000), Currency: "JPY", Unit: entity.SalaryUnitYear}, "年収 5,000,000〜8,000,000円"},
+		{&entity.SalaryRange{Min: intPtr(120000), Currency: "USD"}, "120,000〜 USD"},
+	}
+	for _, tt := range tests {
+		if got := jobparse.FormatSalary(tt.salary); got != tt.want {
+			t.Errorf("FormatSalary(%+v) = %q, want %q", tt.salary, got, tt.want)
+		}
+	}
+}
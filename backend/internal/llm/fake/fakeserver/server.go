@@ -0,0 +1,140 @@
+// Package fakeserver は llm.Provider を OpenAI 互換 API として公開するテスト用の HTTP サーバーです。
+// httptest に依存するため、テストからのみ利用します（API サーバーは fake.Provider を直接使用します）。
+package fakeserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/llm"
+)
+
+// NewServer は provider の応答を OpenAI 互換の /chat/completions として返す httptest サーバーを起動します。
+// openai.Config.BaseURL に Server.URL を指定することで、HTTP 実装をオフラインで検証できます。
+// テストの終了時に Close してください。
+func NewServer(provider llm.Provider) *httptest.Server {
+	return httptest.NewServer(NewHandler(provider))
+}
+
+// NewHandler は provider を OpenAI 互換 API として公開する http.Handler を生成します
+func NewHandler(provider llm.Provider) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/chat/completions", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		var body struct {
+			Messages       []llm.Message `json:"messages"`
+			ResponseFormat *struct {
+				JSONSchema *llm.JSONSchema `json:"json_schema"`
+			} `json:"response_format"`
+			Temperature *float64 `json:"temperature"`
+			MaxTokens   int      `json:"max_tokens"`
+			Stream      bool     `json:"stream"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		req := llm.CompletionRequest{
+			Messages:    body.Messages,
+			Temperature: body.Temperature,
+			MaxTokens:   body.MaxTokens,
+		}
+		if body.ResponseFormat != nil {
+			req.JSONSchema = body.ResponseFormat.JSONSchema
+		}
+
+		if body.Stream {
+			writeStream(w, r, provider, req)
+			return
+		}
+
+		resp, err := provider.Complete(r.Context(), req)
+		if err != nil {
+			writeProviderError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"model": resp.Model,
+			"choices": []map[string]interface{}{
+				{
+					"index":         0,
+					"finish_reason": "stop",
+					"message": map[string]string{
+						"role":    string(llm.RoleAssistant),
+						"content": resp.Content,
+					},
+				},
+			},
+			"usage": resp.Usage,
+		})
+	})
+	return mux
+}
+
+// writeStream は provider の生成結果を OpenAI 互換の SSE 形式（data 行と [DONE]）で返します。
+// provider がストリーミングに対応している場合は差分ごとに送信します。
+func writeStream(w http.ResponseWriter, r *http.Request, provider llm.Provider, req llm.CompletionRequest) {
+	flusher, _ := w.(http.Flusher)
+	started := false
+	writeChunk := func(chunk map[string]interface{}) {
+		if !started {
+			w.Header().Set("Content-Type", "text/event-stream")
+			started = true
+		}
+		data, _ := json.Marshal(chunk)
+		fmt.Fprintf(w, "data: %s\n\n", data)
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+
+	resp, err := llm.Stream(r.Context(), provider, req, func(delta string) error {
+		writeChunk(map[string]interface{}{
+			"choices": []map[string]interface{}{
+				{"index": 0, "delta": map[string]string{"content": delta}},
+			},
+		})
+		return nil
+	})
+	if err != nil {
+		if !started {
+			writeProviderError(w, err)
+		}
+		return
+	}
+	writeChunk(map[string]interface{}{
+		"model": resp.Model,
+		"choices": []map[string]interface{}{
+			{"index": 0, "delta": map[string]string{}, "finish_reason": "stop"},
+		},
+		"usage": resp.Usage,
+	})
+	fmt.Fprint(w, "data: [DONE]\n\n")
+}
+
+// writeProviderError は provider のエラーを OpenAI 互換のエラーレスポンスで返します（利用不可は 503）
+func writeProviderError(w http.ResponseWriter, err error) {
+	status := http.StatusBadRequest
+	if errors.Is(err, llm.ErrUnavailable) {
+		status = http.StatusServiceUnavailable
+	}
+	writeError(w, status, err.Error())
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"error": map[string]string{"message": message},
+	})
+}
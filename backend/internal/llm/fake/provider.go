@@ -0,0 +1,177 @@
+package fake
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/llm"
+)
+
+//go:embed fixtures/*.json
+var defaultFixtures embed.FS
+
+// Fixture は LLM の応答として再生する1件の定型レスポンスです
+type Fixture struct {
+	Name string `json:"name"`
+	// Match が指定されている場合、いずれかのメッセージに Match を含むリクエストにのみ応答します
+	Match   string    `json:"match,omitempty"`
+	Content string    `json:"content"`
+	Usage   llm.Usage `json:"usage"`
+	// Error に "unavailable" を指定すると llm.ErrUnavailable を返します
+	Error string `json:"error,omitempty"`
+}
+
+// Provider はフィクスチャを順番に再生する決定的な llm.Provider です
+type Provider struct {
+	mu       sync.Mutex
+	matched  []Fixture
+	sequence []Fixture
+	cursor   int
+	requests []llm.CompletionRequest
+}
+
+// NewProvider は fixtures を再生する Provider を生成します。
+// Match 付きのフィクスチャが優先され、一致しない場合は Match なしのフィクスチャを順番に循環して返します。
+func NewProvider(fixtures []Fixture) *Provider {
+	p := &Provider{}
+	for _, f := range fixtures {
+		if f.Match != "" {
+			p.matched = append(p.matched, f)
+		} else {
+			p.sequence = append(p.sequence, f)
+		}
+	}
+	return p
+}
+
+// NewDefaultProvider は組み込みのフィクスチャを再生する Provider を生成します
+func NewDefaultProvider() (*Provider, error) {
+	fixtures, err := loadFixtures(defaultFixtures, "fixtures")
+	if err != nil {
+		return nil, err
+	}
+	return NewProvider(fixtures), nil
+}
+
+// LoadFixtures は dir 配下の *.json ファイルからフィクスチャを読み込みます。
+// 各ファイルは Fixture の JSON 配列で、ファイル名順に連結されます。
+func LoadFixtures(dir string) ([]Fixture, error) {
+	return loadFixtures(os.DirFS(dir), ".")
+}
+
+func loadFixtures(fsys fs.FS, dir string) ([]Fixture, error) {
+	paths, err := fs.Glob(fsys, filepath.ToSlash(filepath.Join(dir, "*.json")))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	var fixtures []Fixture
+	for _, path := range paths {
+		data, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return nil, err
+		}
+		var fileFixtures []Fixture
+		if err := json.Unmarshal(data, &fileFixtures); err != nil {
+			return nil, fmt.Errorf("fake: parse %s: %w", path, err)
+		}
+		fixtures = append(fixtures, fileFixtures...)
+	}
+	return fixtures, nil
+}
+
+func (p *Provider) Complete(ctx context.Context, req llm.CompletionRequest) (*llm.CompletionResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("fake: %w", err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.requests = append(p.requests, req)
+
+	fixture, ok := p.next(req)
+	if !ok {
+		return nil, errors.New("fake: no fixture available for request")
+	}
+	if fixture.Error == "unavailable" {
+		return nil, fmt.Errorf("%w: fixture %q", llm.ErrUnavailable, fixture.Name)
+	}
+	if fixture.Error != "" {
+		return nil, fmt.Errorf("fake: %s", fixture.Error)
+	}
+
+	return &llm.CompletionResponse{
+		Content: fixture.Content,
+		Model:   "fake",
+		Usage:   fixture.Usage,
+	}, nil
+}
+
+// streamChunkRunes はストリーミング時に1回の差分として返す文字数です
+const streamChunkRunes = 8
+
+// Stream はフィクスチャの内容を streamChunkRunes 文字ごとに分割して onDelta に渡します
+func (p *Provider) Stream(ctx context.Context, req llm.CompletionRequest, onDelta llm.DeltaFunc) (*llm.CompletionResponse, error) {
+	resp, err := p.Complete(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	for _, chunk := range splitChunks(resp.Content) {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("fake: %w", err)
+		}
+		if err := onDelta(chunk); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+// splitChunks は content を streamChunkRunes 文字ごとに分割します
+func splitChunks(content string) []string {
+	runes := []rune(content)
+	var chunks []string
+	for len(runes) > 0 {
+		n := streamChunkRunes
+		if len(runes) < n {
+			n = len(runes)
+		}
+		chunks = append(chunks, string(runes[:n]))
+		runes = runes[n:]
+	}
+	return chunks
+}
+
+func (p *Provider) next(req llm.CompletionRequest) (Fixture, bool) {
+	for _, f := range p.matched {
+		for _, m := range req.Messages {
+			if strings.Contains(m.Content, f.Match) {
+				return f, true
+			}
+		}
+	}
+	if len(p.sequence) == 0 {
+		return Fixture{}, false
+	}
+	f := p.sequence[p.cursor%len(p.sequence)]
+	p.cursor++
+	return f, true
+}
+
+// Requests はこれまでに受け取ったリクエストを返します
+func (p *Provider) Requests() []llm.CompletionRequest {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]llm.CompletionRequest(nil), p.requests...)
+}
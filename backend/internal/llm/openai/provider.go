@@ -0,0 +1,228 @@
+package openai
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/llm"
+)
+
+const defaultBaseURL = "https://api.openai.com/v1"
+
+// Config は OpenAI 互換 API への接続設定です
+type Config struct {
+	BaseURL string
+	APIKey  string
+	Model   string
+	Timeout time.Duration
+}
+
+type provider struct {
+	config Config
+	client *http.Client
+}
+
+// NewProvider は OpenAI 互換の Chat Completions API を利用する Provider を生成します
+func NewProvider(config Config) llm.Provider {
+	if config.BaseURL == "" {
+		config.BaseURL = defaultBaseURL
+	}
+	config.BaseURL = strings.TrimRight(config.BaseURL, "/")
+	return &provider{
+		config: config,
+		client: &http.Client{Timeout: config.Timeout},
+	}
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type responseFormat struct {
+	Type       string          `json:"type"`
+	JSONSchema *llm.JSONSchema `json:"json_schema,omitempty"`
+}
+
+type chatRequest struct {
+	Model          string          `json:"model"`
+	Messages       []chatMessage   `json:"messages"`
+	ResponseFormat *responseFormat `json:"response_format,omitempty"`
+	Temperature    *float64        `json:"temperature,omitempty"`
+	MaxTokens      int             `json:"max_tokens,omitempty"`
+	Stream         bool            `json:"stream,omitempty"`
+}
+
+type chatResponse struct {
+	Model   string `json:"model"`
+	Choices []struct {
+		Message struct {
+			Content string `json:"content"`
+		} `json:"message"`
+	} `json:"choices"`
+	Usage llm.Usage `json:"usage"`
+}
+
+type errorResponse struct {
+	Error struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func (p *provider) Complete(ctx context.Context, req llm.CompletionRequest) (*llm.CompletionResponse, error) {
+	resp, err := p.send(ctx, req, false)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("openai: read response: %w", err)
+	}
+
+	var chatResp chatResponse
+	if err := json.Unmarshal(respBody, &chatResp); err != nil {
+		return nil, fmt.Errorf("openai: decode response: %w", err)
+	}
+	if len(chatResp.Choices) == 0 {
+		return nil, errors.New("openai: response has no choices")
+	}
+
+	return &llm.CompletionResponse{
+		Content: chatResp.Choices[0].Message.Content,
+		Model:   chatResp.Model,
+		Usage:   chatResp.Usage,
+	}, nil
+}
+
+type chatStreamChunk struct {
+	Model   string `json:"model"`
+	Choices []struct {
+		Delta struct {
+			Content string `json:"content"`
+		} `json:"delta"`
+	} `json:"choices"`
+	Usage *llm.Usage `json:"usage"`
+}
+
+// Stream は stream: true で Chat Completions API を呼び出し、data 行ごとの差分を onDelta に渡します
+func (p *provider) Stream(ctx context.Context, req llm.CompletionRequest, onDelta llm.DeltaFunc) (*llm.CompletionResponse, error) {
+	resp, err := p.send(ctx, req, true)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	result := &llm.CompletionResponse{}
+	var content strings.Builder
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "data:") {
+			continue
+		}
+		data := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+		if data == "[DONE]" {
+			break
+		}
+
+		var chunk chatStreamChunk
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			return nil, fmt.Errorf("openai: decode stream chunk: %w", err)
+		}
+		if chunk.Model != "" {
+			result.Model = chunk.Model
+		}
+		if chunk.Usage != nil {
+			result.Usage = *chunk.Usage
+		}
+		for _, choice := range chunk.Choices {
+			if choice.Delta.Content == "" {
+				continue
+			}
+			content.WriteString(choice.Delta.Content)
+			if err := onDelta(choice.Delta.Content); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, fmt.Errorf("openai: %w", ctxErr)
+		}
+		return nil, fmt.Errorf("%w: read stream: %v", llm.ErrUnavailable, err)
+	}
+
+	result.Content = content.String()
+	return result, nil
+}
+
+// send はリクエストを送信し、ステータスが 200 のレスポンスを返します。呼び出し元で Body を閉じてください。
+func (p *provider) send(ctx context.Context, req llm.CompletionRequest, stream bool) (*http.Response, error) {
+	body := chatRequest{
+		Model:       p.config.Model,
+		Temperature: req.Temperature,
+		MaxTokens:   req.MaxTokens,
+		Stream:      stream,
+	}
+	for _, m := range req.Messages {
+		body.Messages = append(body.Messages, chatMessage{Role: string(m.Role), Content: m.Content})
+	}
+	if req.JSONSchema != nil {
+		body.ResponseFormat = &responseFormat{Type: "json_schema", JSONSchema: req.JSONSchema}
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("openai: marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.config.BaseURL+"/chat/completions", bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("openai: build request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if stream {
+		httpReq.Header.Set("Accept", "text/event-stream")
+	}
+	if p.config.APIKey != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+p.config.APIKey)
+	}
+
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		// キャンセル・期限切れは呼び出し元で判別できるよう ctx のエラーをそのまま伝える
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, fmt.Errorf("openai: %w", ctxErr)
+		}
+		return nil, fmt.Errorf("%w: %v", llm.ErrUnavailable, err)
+	}
+	if resp.StatusCode == http.StatusOK {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("openai: read response: %w", err)
+	}
+	message := strings.TrimSpace(string(respBody))
+	var errResp errorResponse
+	if json.Unmarshal(respBody, &errResp) == nil && errResp.Error.Message != "" {
+		message = errResp.Error.Message
+	}
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError {
+		return nil, fmt.Errorf("%w: status %d: %s", llm.ErrUnavailable, resp.StatusCode, message)
+	}
+	return nil, fmt.Errorf("openai: status %d: %s", resp.StatusCode, message)
+}
@@ -0,0 +1,139 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/llm"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/llm/fake"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/llm/fake/fakeserver"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/llm/openai"
+)
+
+var evaluationSchema = &llm.JSONSchema{
+	Name:   "answer_evaluation",
+	Strict: true,
+	Schema: json.RawMessage(`{"type":"object","properties":{"score":{"type":"integer"}},"required":["score"],"additionalProperties":false}`),
+}
+
+// newProvider は fixtures を再生する偽の API サーバーに接続した Provider を返します
+func newProvider(t *testing.T, fixtures ...fake.Fixture) (llm.Provider, *fake.Provider) {
+	t.Helper()
+	backend := fake.NewProvider(fixtures)
+	server := fakeserver.NewServer(backend)
+	t.Cleanup(server.Close)
+	return openai.NewProvider(openai.Config{BaseURL: server.URL, APIKey: "test", Model: "test-model", Timeout: 5 * time.Second}), backend
+}
+
+func TestCompleteJSONSchema(t *testing.T) {
+	usage := llm.Usage{PromptTokens: 120, CompletionTokens: 30, TotalTokens: 150}
+	provider, backend := newProvider(t, fake.Fixture{Name: "evaluation", Content: `{"score": 80}`, Usage: usage})
+
+	resp, err := provider.Complete(context.Background(), llm.CompletionRequest{
+		Messages:   []llm.Message{llm.UserMessage("回答を評価してください")},
+		JSONSchema: evaluationSchema,
+	})
+	if err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+
+	var out struct {
+		Score int `json:"score"`
+	}
+	if err := json.Unmarshal([]byte(resp.Content), &out); err != nil || out.Score != 80 {
+		t.Errorf("Content = %q, want JSON with score 80", resp.Content)
+	}
+	if resp.Usage != usage {
+		t.Errorf("Usage = %+v, want %+v", resp.Usage, usage)
+	}
+	if resp.Model != "fake" {
+		t.Errorf("Model = %q, want %q", resp.Model, "fake")
+	}
+
+	requests := backend.Requests()
+	if len(requests) != 1 {
+		t.Fatalf("server received %d requests, want 1", len(requests))
+	}
+	got := requests[0].JSONSchema
+	if got == nil || got.Name != evaluationSchema.Name || !got.Strict {
+		t.Fatalf("JSONSchema sent = %+v, want %q (strict)", got, evaluationSchema.Name)
+	}
+	var schema map[string]interface{}
+	if err := json.Unmarshal(got.Schema, &schema); err != nil || schema["type"] != "object" {
+		t.Errorf("schema sent = %s, want the request schema", got.Schema)
+	}
+	if requests[0].Messages[0].Content != "回答を評価してください" {
+		t.Errorf("message sent = %q", requests[0].Messages[0].Content)
+	}
+}
+
+func TestStreamReportsUsage(t *testing.T) {
+	usage := llm.Usage{PromptTokens: 50, CompletionTokens: 20, TotalTokens: 70}
+	content := "本日は面接にお時間をいただき、ありがとうございます。"
+	provider, _ := newProvider(t, fake.Fixture{Name: "greeting", Content: content, Usage: usage})
+
+	var deltas []string
+	resp, err := llm.Stream(context.Background(), provider, llm.CompletionRequest{
+		Messages: []llm.Message{llm.UserMessage("挨拶してください")},
+	}, func(delta string) error {
+		deltas = append(deltas, delta)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Stream() error = %v", err)
+	}
+	if len(deltas) < 2 {
+		t.Errorf("received %d deltas, want the content split into several chunks", len(deltas))
+	}
+	if joined := strings.Join(deltas, ""); joined != content || resp.Content != content {
+		t.Errorf("deltas = %q, Content = %q, want %q", joined, resp.Content, content)
+	}
+	if resp.Usage != usage {
+		t.Errorf("Usage = %+v, want %+v", resp.Usage, usage)
+	}
+}
+
+func TestCompleteUnavailable(t *testing.T) {
+	provider, _ := newProvider(t, fake.Fixture{Name: "down", Error: "unavailable"})
+
+	_, err := provider.Complete(context.Background(), llm.CompletionRequest{
+		Messages: []llm.Message{llm.UserMessage("hello")},
+	})
+	if !errors.Is(err, llm.ErrUnavailable) {
+		t.Errorf("Complete() error = %v, want llm.ErrUnavailable", err)
+	}
+}
+
+// blockingProvider はリクエストがキャンセルされるまで応答しない Provider です
+type blockingProvider struct{}
+
+func (blockingProvider) Complete(ctx context.Context, req llm.CompletionRequest) (*llm.CompletionResponse, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestCompleteCancellation(t *testing.T) {
+	server := fakeserver.NewServer(blockingProvider{})
+	t.Cleanup(server.Close)
+	provider := openai.NewProvider(openai.Config{BaseURL: server.URL, Timeout: 5 * time.Second})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	started := time.Now()
+	_, err := provider.Complete(ctx, llm.CompletionRequest{
+		Messages: []llm.Message{llm.UserMessage("hello")},
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Complete() error = %v, want context.Canceled", err)
+	}
+	if errors.Is(err, llm.ErrUnavailable) {
+		t.Errorf("Complete() error = %v, cancellation must not be reported as unavailable", err)
+	}
+	if elapsed := time.Since(started); elapsed > 2*time.Second {
+		t.Errorf("Complete() returned after %v, want it to return promptly on cancellation", elapsed)
+	}
+}
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/auth"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/usecase/user"
+)
+
+// Auth は Authorization ヘッダーの Bearer トークンを検証し、認証済みのユーザーを request の context に格納します。
+// ブラウザの WebSocket はヘッダーを指定できないため、Upgrade リクエストに限り access_token クエリパラメータも受け付けます。
+func Auth(verifier auth.Verifier, users user.UseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := bearerToken(c.Request)
+		if token == "" {
+			c.Error(auth.ErrUnauthenticated)
+			c.Abort()
+			return
+		}
+
+		claims, err := verifier.Verify(c.Request.Context(), token)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		authenticated, err := users.Authenticate(c.Request.Context(), claims)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.Request = c.Request.WithContext(auth.WithUser(c.Request.Context(), authenticated))
+		c.Next()
+	}
+}
+
+func bearerToken(r *http.Request) string {
+	if header := r.Header.Get("Authorization"); header != "" {
+		scheme, token, ok := strings.Cut(header, " ")
+		if !ok || !strings.EqualFold(scheme, "Bearer") {
+			return ""
+		}
+		return strings.TrimSpace(token)
+	}
+	if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+		return r.URL.Query().Get("access_token")
+	}
+	return ""
+}
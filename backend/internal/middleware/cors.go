@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AllowedOrigins はクロスオリジンのリクエストを許可するオリジンの一覧です。"*" を含む場合は全てのオリジンを許可します。
+type AllowedOrigins []string
+
+// ParseAllowedOrigins はカンマ区切りのオリジンの一覧を解析します（末尾の "/" は無視します）。
+func ParseAllowedOrigins(value string) AllowedOrigins {
+	var origins AllowedOrigins
+	for _, origin := range strings.Split(value, ",") {
+		origin = strings.TrimRight(strings.TrimSpace(origin), "/")
+		if origin != "" {
+			origins = append(origins, origin)
+		}
+	}
+	return origins
+}
+
+// Allows は origin からのリクエストを許可するかを返します。オリジンの比較は大文字・小文字を区別しません。
+func (o AllowedOrigins) Allows(origin string) bool {
+	for _, allowed := range o {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckOrigin は WebSocket の接続元を許可されたオリジンに制限する関数を返します（websocket.Upgrader の CheckOrigin に設定します）。
+// Origin ヘッダーのないリクエストはブラウザ以外のクライアントからの接続として許可します。
+func (o AllowedOrigins) CheckOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	return origin == "" || o.Allows(origin)
+}
+
+// CORS は許可されたオリジンからのリクエストに CORS のレスポンスヘッダーを設定します。
+// 許可されていないオリジンには Access-Control-Allow-Origin を返さないため、ブラウザがレスポンスを破棄します。
+func CORS(origins AllowedOrigins) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Writer.Header().Add("Vary", "Origin")
+		if origin := c.GetHeader("Origin"); origin != "" && origins.Allows(origin) {
+			c.Writer.Header().Set("Access-Control-Allow-Origin", origin)
+			c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+			c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, If-Match, If-None-Match, "+OrganizationHeader)
+			c.Writer.Header().Set("Access-Control-Expose-Headers", "ETag, Content-Disposition")
+		}
+		if c.Request.Method == "OPTIONS" {
+			c.AbortWithStatus(204)
+			return
+		}
+		c.Next()
+	}
+}
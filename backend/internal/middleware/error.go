@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"log"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/apperror"
+)
+
+// ErrorHandler はハンドラー・ミドルウェアが c.Error で登録したエラーを共通のエラーレスポンス形式で返します。
+//
+//	{"error": {"code": "NOT_FOUND", "message": "company not found", "details": [{"field": "...", "message": "..."}]}}
+//
+// 種別を持たないエラーは 500 とし、原因のメッセージ（SQL など）はログにのみ出力します。
+func ErrorHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		err := c.Errors.Last().Err
+		appErr := apperror.From(err)
+		if appErr.Code == apperror.CodeInternal {
+			log.Printf("%s %s: %v", c.Request.Method, c.Request.URL.Path, err)
+		}
+		c.AbortWithStatusJSON(appErr.Status(), gin.H{"error": appErr})
+	}
+}
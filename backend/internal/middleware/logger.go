@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// redactedQueryParams はアクセスログに値を出力しないクエリパラメータです。
+// WebSocket の接続ではアクセストークンをクエリパラメータ access_token で受け取るため、ログに残らないようにします。
+var redactedQueryParams = []string{"access_token"}
+
+// Logger は gin.Logger と同じ形式でアクセスログを出力します。クエリパラメータのアクセストークンは伏せ字にします。
+func Logger() gin.HandlerFunc {
+	return gin.LoggerWithFormatter(func(params gin.LogFormatterParams) string {
+		if params.Latency > time.Minute {
+			params.Latency = params.Latency.Truncate(time.Second)
+		}
+		return fmt.Sprintf("[GIN] %v | %3d | %13v | %15s | %-7s %#v\n%s",
+			params.TimeStamp.Format("2006/01/02 - 15:04:05"),
+			params.StatusCode,
+			params.Latency,
+			params.ClientIP,
+			params.Method,
+			redactQuery(params.Path),
+			params.ErrorMessage,
+		)
+	})
+}
+
+// redactQuery はパス（クエリ文字列を含む）の redactedQueryParams の値を伏せ字にします
+func redactQuery(path string) string {
+	p, rawQuery, ok := strings.Cut(path, "?")
+	if !ok {
+		return path
+	}
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		// 解析できないクエリ文字列にトークンが含まれている可能性があるため、クエリ文字列ごと出力しない
+		return p + "?REDACTED"
+	}
+	redacted := false
+	for _, name := range redactedQueryParams {
+		if query.Has(name) {
+			query.Set(name, "REDACTED")
+			redacted = true
+		}
+	}
+	if !redacted {
+		return path
+	}
+	return p + "?" + query.Encode()
+}
@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/apperror"
+)
+
+// errIfMatchRequired は厳格モードで If-Match ヘッダーなしに更新・削除しようとした場合に返されます
+var errIfMatchRequired = apperror.PreconditionRequired("If-Match header is required")
+
+// RequireIfMatch は更新・削除のルートに設定し、strict が true の場合は If-Match ヘッダーを必須にします（428）。
+// strict が false の場合、If-Match のないリクエストはバージョンを確認せずに処理します。
+func RequireIfMatch(strict bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if strict && c.GetHeader("If-Match") == "" {
+			c.Error(errIfMatchRequired)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/apperror"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/auth"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/usecase/organization"
+)
+
+// OrganizationHeader はリクエスト対象の組織ワークスペースを指定するヘッダーです
+const OrganizationHeader = "X-Organization-ID"
+
+// Workspace は X-Organization-ID ヘッダーで指定された組織へのユーザーの所属を確認し、request の context に格納します。
+// ヘッダーがない場合は個人のワークスペースとして扱います。Auth の後に登録してください。
+func Workspace(organizations organization.UseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader(OrganizationHeader)
+		if header == "" {
+			c.Next()
+			return
+		}
+
+		organizationID, err := strconv.Atoi(header)
+		if err != nil {
+			c.Error(apperror.Validation("invalid "+OrganizationHeader+" header",
+				apperror.Detail{Field: OrganizationHeader, Message: "must be an integer"}))
+			c.Abort()
+			return
+		}
+
+		membership, err := organizations.GetMembership(c.Request.Context(), organizationID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				err = apperror.Forbidden("not a member of the organization")
+			}
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.Request = c.Request.WithContext(auth.WithMembership(c.Request.Context(), membership))
+		c.Next()
+	}
+}
+
+// RequireRole は組織ワークスペースでのリクエストを roles のいずれかのロールを持つメンバーに限定します。
+// 個人のワークスペースではユーザー自身が全ての操作を行えるため、常に許可します。
+func RequireRole(roles ...entity.MembershipRole) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		membership, ok := auth.MembershipFromContext(c.Request.Context())
+		if !ok {
+			c.Next()
+			return
+		}
+		for _, role := range roles {
+			if membership.Role == role {
+				c.Next()
+				return
+			}
+		}
+		c.Error(organization.ErrForbidden)
+		c.Abort()
+	}
+}
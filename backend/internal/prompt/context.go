@@ -0,0 +1,120 @@
+package prompt
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/htmldoc"
+)
+
+// DefaultContextMaxRunes は企業・求人情報ブロックの既定の最大文字数です
+const DefaultContextMaxRunes = 2000
+
+const truncatedSuffix = "…（以下省略）"
+
+// InterviewData は面接プロンプトのテンプレートに渡すデータです
+type InterviewData struct {
+	CompanyName       string
+	CompanyDetails    string
+	JobPostingDetails string
+	InterviewPhase    string
+	InterviewerRole   string
+	QuestionCount     int
+	InterviewerName   string
+	ApplicantName     string
+	// History は質問履歴の JSON 表現です。挨拶フェーズでは空になります。
+	History string
+}
+
+// AnswerEvaluationData は回答評価プロンプトのテンプレートに渡すデータです
+type AnswerEvaluationData struct {
+	CompanyDetails    string
+	JobPostingDetails string
+	InterviewPhase    string
+	QuestionContent   string
+	AnswerContent     string
+}
+
+// OverallEvaluationData は総評生成プロンプトのテンプレートに渡すデータです
+type OverallEvaluationData struct {
+	CompanyDetails    string
+	JobPostingDetails string
+	InterviewPhase    string
+	// Results は回答評価結果一覧の JSON 表現です
+	Results    string
+	Scores     entity.EvaluationScores
+	TotalScore int
+	TotalRank  entity.EvaluationRank
+}
+
+// JobPostingExtractionData は求人票の項目抽出プロンプトのテンプレートに渡すデータです
+type JobPostingExtractionData struct {
+	// Text は求人票のテキストです（HTML はテキストに変換済み）
+	Text string
+}
+
+// HistoryItem は質問履歴の1件を表します
+type HistoryItem struct {
+	Question string `json:"question"`
+	Answer   string `json:"answer"`
+}
+
+// CompanyDetails は企業名・事業内容・カスタムフィールドを maxRunes 文字以内のブロックに整形します
+func CompanyDetails(company *entity.Company, maxRunes int) string {
+	if company == nil {
+		return ""
+	}
+	lines := []string{fmt.Sprintf("企業名：%s", company.Name)}
+	if company.BusinessDescription != nil && *company.BusinessDescription != "" {
+		lines = append(lines, fmt.Sprintf("事業内容：%s", *company.BusinessDescription))
+	}
+	for _, f := range company.CustomFields {
+		lines = append(lines, fmt.Sprintf("%s：%s", f.FieldName, f.Content))
+	}
+	return truncate(strings.Join(lines, "\n"), maxRunes)
+}
+
+// JobPostingDetails は求人タイトル・仕事内容・カスタムフィールドを maxRunes 文字以内のブロックに整形します
+func JobPostingDetails(jobPosting *entity.JobPosting, maxRunes int) string {
+	if jobPosting == nil {
+		return ""
+	}
+	lines := []string{fmt.Sprintf("求人タイトル：%s", jobPosting.Title)}
+	if jobPosting.Description != nil && *jobPosting.Description != "" {
+		lines = append(lines, fmt.Sprintf("仕事内容：%s", *jobPosting.Description))
+	}
+	for _, f := range jobPosting.CustomFields {
+		lines = append(lines, fmt.Sprintf("%s：%s", f.FieldName, f.Content))
+	}
+	return truncate(strings.Join(lines, "\n"), maxRunes)
+}
+
+// FormatHistory は質問履歴を JSON 文字列に整形します
+func FormatHistory(items []HistoryItem) string {
+	if items == nil {
+		items = []HistoryItem{}
+	}
+	b, err := json.MarshalIndent(items, "", "  ")
+	if err != nil {
+		return "[]"
+	}
+	return string(b)
+}
+
+// truncate は s が maxRunes 文字を超える場合に末尾を省略します
+func truncate(s string, maxRunes int) string {
+	if maxRunes <= 0 {
+		return s
+	}
+	if utf8.RuneCountInString(s) <= maxRunes {
+		return s
+	}
+	suffix := utf8.RuneCountInString(truncatedSuffix)
+	if maxRunes <= suffix {
+		return htmldoc.Truncate(s, maxRunes)
+	}
+	return htmldoc.Truncate(s, maxRunes-suffix) + truncatedSuffix
+}
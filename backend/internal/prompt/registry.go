@@ -0,0 +1,129 @@
+package prompt
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// 組み込みのプロンプトテンプレート。templates/<バージョン>/<名前>.tmpl の構成で配置します。
+//
+//go:embed templates
+var embeddedTemplates embed.FS
+
+const templateExt = ".tmpl"
+
+// テンプレート名
+const (
+	InterviewSystem           = "interview_system"
+	InterviewGreeting         = "interview_greeting"
+	InterviewSelfIntroduction = "interview_self_introduction"
+	InterviewIceBreak         = "interview_ice_break"
+	InterviewMain             = "interview_main"
+	AnswerEvaluation          = "answer_evaluation"
+	OverallEvaluation         = "overall_evaluation"
+	JobPostingExtraction      = "job_posting_extraction"
+)
+
+// Registry は特定バージョンのプロンプトテンプレート群を保持します
+type Registry struct {
+	version   string
+	templates *template.Template
+}
+
+// NewRegistry はプロンプトテンプレートを読み込みます。
+// overrideDir が指定されている場合、<overrideDir>/<バージョン>/<名前>.tmpl が組み込みテンプレートを上書き・追加します。
+// version が空の場合は利用可能な最新バージョンを使用します。
+func NewRegistry(overrideDir, version string) (*Registry, error) {
+	embedded, err := fs.Sub(embeddedTemplates, "templates")
+	if err != nil {
+		return nil, err
+	}
+	sources := []fs.FS{embedded}
+	if overrideDir != "" {
+		sources = append(sources, os.DirFS(overrideDir))
+	}
+
+	if version == "" {
+		version, err = latestVersion(sources)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	templates := template.New(version)
+	found := false
+	for _, src := range sources {
+		files, err := fs.Glob(src, path.Join(version, "*"+templateExt))
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			content, err := fs.ReadFile(src, file)
+			if err != nil {
+				return nil, err
+			}
+			name := strings.TrimSuffix(path.Base(file), templateExt)
+			// 同名のテンプレートは後から読み込んだもので置き換えられる
+			if _, err := templates.New(name).Parse(string(content)); err != nil {
+				return nil, fmt.Errorf("prompt: parse %s: %w", file, err)
+			}
+			found = true
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("prompt: no templates found for version %q", version)
+	}
+
+	return &Registry{version: version, templates: templates}, nil
+}
+
+// Version は使用中のプロンプトバージョンを返します
+func (r *Registry) Version() string {
+	return r.version
+}
+
+// Render は name のテンプレートに data を適用した文字列を返します
+func (r *Registry) Render(name string, data interface{}) (string, error) {
+	t := r.templates.Lookup(name)
+	if t == nil {
+		return "", fmt.Errorf("prompt: template %q not found in version %s", name, r.version)
+	}
+	var b strings.Builder
+	if err := t.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("prompt: render %s: %w", name, err)
+	}
+	return strings.TrimSpace(b.String()), nil
+}
+
+// latestVersion は v1, v2, ... 形式のディレクトリのうち最も新しいバージョンを返します
+func latestVersion(sources []fs.FS) (string, error) {
+	latest, latestNum := "", -1
+	for _, src := range sources {
+		entries, err := fs.ReadDir(src, ".")
+		if err != nil {
+			return "", err
+		}
+		for _, e := range entries {
+			if !e.IsDir() || !strings.HasPrefix(e.Name(), "v") {
+				continue
+			}
+			n, err := strconv.Atoi(strings.TrimPrefix(e.Name(), "v"))
+			if err != nil {
+				continue
+			}
+			if n > latestNum {
+				latest, latestNum = e.Name(), n
+			}
+		}
+	}
+	if latest == "" {
+		return "", fmt.Errorf("prompt: no template versions found")
+	}
+	return latest, nil
+}
@@ -0,0 +1,318 @@
+package company
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/repository"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/repository/transaction"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/repository/workspace"
+)
+
+// jobPostingCountExpr は企業の求人情報の件数（ゴミ箱にあるものを除く）を求める相関サブクエリです
+const jobPostingCountExpr = "(SELECT COUNT(*) FROM job_postings j WHERE j.company_id = companies.id AND j.deleted_at IS NULL)"
+
+func (r *companyRepository) GetCompanies(ctx context.Context, query repository.CompanyQuery) (*entity.CompanyResponse, error) {
+	db, err := r.owned(ctx)
+	if err != nil {
+		return nil, err
+	}
+	db, pagination, err := paginate(db.Model(&entity.Company{}).Scopes(filterCompanies(query)).Session(&gorm.Session{}), query)
+	if err != nil {
+		return nil, err
+	}
+
+	// 企業情報を取得（include で指定した関連のみ）
+	if query.Include.CustomFields {
+		db = db.Preload("CustomFields")
+	}
+	if query.Include.JobPostings {
+		db = db.Preload("JobPostings").Preload("JobPostings.CustomFields")
+	}
+	var companies []entity.Company
+	if err := db.Find(&companies).Error; err != nil {
+		return nil, err
+	}
+
+	if query.Cursor != nil && len(companies) > query.Limit {
+		companies = companies[:query.Limit]
+		last := companies[len(companies)-1]
+		var value interface{}
+		switch query.Sort {
+		case repository.CompanySortName:
+			value = last.Name
+		case repository.CompanySortCreatedAt:
+			value = last.CreatedAt
+		case repository.CompanySortUpdatedAt:
+			value = last.UpdatedAt
+		case repository.CompanySortJobPostingCount:
+			var count int64
+			if err := transaction.Conn(ctx, r.db).Model(&entity.JobPosting{}).Where("company_id = ?", last.ID).Count(&count).Error; err != nil {
+				return nil, err
+			}
+			value = count
+		}
+		pagination.NextCursor = encodeCursor(query, last.ID, value)
+	}
+
+	return &entity.CompanyResponse{Companies: companies, Pagination: pagination}, nil
+}
+
+func (r *companyRepository) GetCompanySummaries(ctx context.Context, query repository.CompanyQuery) (*entity.CompanySummaryResponse, error) {
+	scope, err := workspace.Companies(ctx)
+	if err != nil {
+		return nil, err
+	}
+	db := transaction.Conn(ctx, r.db).Model(&entity.Company{}).Scopes(scope, filterCompanies(query)).Session(&gorm.Session{})
+	db, pagination, err := paginate(db, query)
+	if err != nil {
+		return nil, err
+	}
+
+	// 次のカーソルを作成できるよう、並び順の項目は fields になくても取得する
+	fields := query.Fields
+	if sortField := repository.CompanySummaryField(query.Sort); query.Sort != repository.CompanySortID && query.Sort != "" {
+		fields = append(fields, sortField)
+	}
+
+	// 件数は企業ごとに集計したサブクエリを結合して求める（子のレコードを読み込まない）
+	ownedCompanyIDs := r.db.Model(&entity.Company{}).Select("companies.id").Scopes(scope)
+	columns := []string{"companies.id"}
+	for _, field := range fields {
+		switch field {
+		case repository.CompanySummaryFieldName, repository.CompanySummaryFieldBusinessDescription,
+			repository.CompanySummaryFieldVersion, repository.CompanySummaryFieldCreatedAt, repository.CompanySummaryFieldUpdatedAt:
+			columns = append(columns, "companies."+string(field))
+		case repository.CompanySummaryFieldJobPostingCount:
+			columns = append(columns, "COALESCE(jc.job_posting_count, 0) AS job_posting_count")
+			db = db.Joins("LEFT JOIN (?) AS jc ON jc.company_id = companies.id",
+				r.db.Model(&entity.JobPosting{}).
+					Select("company_id, COUNT(*) AS job_posting_count").
+					Where("company_id IN (?)", ownedCompanyIDs).
+					Group("company_id"))
+		case repository.CompanySummaryFieldCustomFieldCount:
+			columns = append(columns, "COALESCE(fc.custom_field_count, 0) AS custom_field_count")
+			db = db.Joins("LEFT JOIN (?) AS fc ON fc.company_id = companies.id",
+				r.db.Model(&entity.CompanyCustomField{}).
+					Select("company_id, COUNT(*) AS custom_field_count").
+					Where("company_id IN (?)", ownedCompanyIDs).
+					Group("company_id"))
+		}
+	}
+
+	var summaries []entity.CompanySummary
+	if err := db.Select(uniqueColumns(columns)).Find(&summaries).Error; err != nil {
+		return nil, err
+	}
+
+	if query.Cursor != nil && len(summaries) > query.Limit {
+		summaries = summaries[:query.Limit]
+		last := summaries[len(summaries)-1]
+		var value interface{}
+		switch query.Sort {
+		case repository.CompanySortName:
+			value = last.Name
+		case repository.CompanySortCreatedAt:
+			value = last.CreatedAt
+		case repository.CompanySortUpdatedAt:
+			value = last.UpdatedAt
+		case repository.CompanySortJobPostingCount:
+			value = last.JobPostingCount
+		}
+		pagination.NextCursor = encodeCursor(query, last.ID, value)
+	}
+
+	return &entity.CompanySummaryResponse{Companies: summaries, Pagination: pagination}, nil
+}
+
+// uniqueColumns は重複した列を取り除きます（fields と並び順で同じ項目を指定した場合）
+func uniqueColumns(columns []string) []string {
+	seen := make(map[string]bool, len(columns))
+	unique := columns[:0]
+	for _, column := range columns {
+		if !seen[column] {
+			seen[column] = true
+			unique = append(unique, column)
+		}
+	}
+	return unique
+}
+
+// paginate は並び順とページングを設定したクエリを返します。
+// ページ番号でのページングでは総件数を数え、カーソルでのページングでは次のページの有無を判定するため limit より1件多く取得します。
+func paginate(db *gorm.DB, query repository.CompanyQuery) (*gorm.DB, entity.Pagination, error) {
+	pagination := entity.Pagination{Limit: query.Limit}
+
+	if query.Cursor == nil {
+		var total int64
+		if err := db.Count(&total).Error; err != nil {
+			return nil, pagination, err
+		}
+		count := int(total)
+		pagination.Total = &count
+		pagination.Page = query.Page
+		return db.Order(orderCompanies(query)).Offset((query.Page - 1) * query.Limit).Limit(query.Limit), pagination, nil
+	}
+
+	if *query.Cursor != "" {
+		condition, args, err := decodeCursor(query, *query.Cursor)
+		if err != nil {
+			return nil, pagination, err
+		}
+		db = db.Where(condition, args...)
+	}
+	return db.Order(orderCompanies(query)).Limit(query.Limit + 1), pagination, nil
+}
+
+// ngramTokenSize は全文検索の ngram パーサーのトークン長です（mysql/my.cnf の ngram_token_size）
+const ngramTokenSize = 2
+
+// filterCompanies は企業一覧の検索条件のスコープを返します
+func filterCompanies(query repository.CompanyQuery) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		// 語ごとに企業名・事業内容・追加情報・求人タイトルのいずれかに含む企業に絞り込む（全ての語を含む企業が対象）
+		for _, keyword := range query.Keywords {
+			term := booleanModeTerm(keyword)
+			if term == "" {
+				continue
+			}
+			db = db.Where(`companies.id IN (
+				SELECT c.id FROM companies c WHERE MATCH(c.name, c.business_description) AGAINST (? IN BOOLEAN MODE)
+				UNION SELECT f.company_id FROM company_custom_fields f WHERE MATCH(f.content) AGAINST (? IN BOOLEAN MODE)
+				UNION SELECT j.company_id FROM job_postings j WHERE j.deleted_at IS NULL AND MATCH(j.title) AGAINST (? IN BOOLEAN MODE)
+			)`, term, term, term)
+		}
+		if query.HasJobPostings != nil {
+			exists := "EXISTS (SELECT 1 FROM job_postings j WHERE j.company_id = companies.id AND j.deleted_at IS NULL)"
+			if !*query.HasJobPostings {
+				exists = "NOT " + exists
+			}
+			db = db.Where(exists)
+		}
+		if query.CreatedAfter != nil {
+			db = db.Where("companies.created_at >= ?", *query.CreatedAfter)
+		}
+		return db
+	}
+}
+
+// booleanModeTerm は全文検索（BOOLEAN MODE）の必須の語を作成します。
+// 演算子として扱われる記号は空白に置き換えて語全体をフレーズとして照合し、ngram のトークン長より短い語は前方一致で照合します。
+func booleanModeTerm(keyword string) string {
+	keyword = strings.TrimSpace(strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`"+-<>()~*@`, r) {
+			return ' '
+		}
+		return r
+	}, keyword))
+	switch {
+	case keyword == "":
+		return ""
+	case utf8.RuneCountInString(keyword) < ngramTokenSize:
+		return "+" + keyword + "*"
+	default:
+		return `+"` + keyword + `"`
+	}
+}
+
+// sortColumn は並び順の基準の列（式）を返します。ID 順の場合は空文字列を返します。
+func sortColumn(sort repository.CompanySort) string {
+	switch sort {
+	case repository.CompanySortName:
+		return "companies.name"
+	case repository.CompanySortCreatedAt:
+		return "companies.created_at"
+	case repository.CompanySortUpdatedAt:
+		return "companies.updated_at"
+	case repository.CompanySortJobPostingCount:
+		return jobPostingCountExpr
+	default:
+		return ""
+	}
+}
+
+// orderCompanies は企業一覧の ORDER BY 句を返します。
+// ページングで企業が重複・欠落しないよう、同じ値の企業は ID の昇順に並べます。
+func orderCompanies(query repository.CompanyQuery) string {
+	direction := "ASC"
+	if query.Desc {
+		direction = "DESC"
+	}
+	column := sortColumn(query.Sort)
+	if column == "" {
+		return "companies.id " + direction
+	}
+	return column + " " + direction + ", companies.id ASC"
+}
+
+// companyCursor はキーセットページングのカーソルの内容です。前のページの最後の企業の並び順の値と ID を持ちます。
+type companyCursor struct {
+	Sort  repository.CompanySort `json:"s"`
+	Desc  bool                   `json:"d,omitempty"`
+	Value json.RawMessage        `json:"v,omitempty"`
+	ID    int                    `json:"id"`
+}
+
+// encodeCursor は最後の企業の ID と並び順の値からカーソルを作成します
+func encodeCursor(query repository.CompanyQuery, id int, value interface{}) *string {
+	cursor := companyCursor{Sort: query.Sort, Desc: query.Desc, ID: id}
+	if value != nil {
+		cursor.Value, _ = json.Marshal(value)
+	}
+	data, _ := json.Marshal(cursor)
+	encoded := base64.RawURLEncoding.EncodeToString(data)
+	return &encoded
+}
+
+// decodeCursor はカーソルを解析し、カーソルより後の企業に絞り込む条件を返します
+func decodeCursor(query repository.CompanyQuery, encoded string) (string, []interface{}, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", nil, repository.ErrInvalidCursor
+	}
+	var cursor companyCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.Sort != query.Sort || cursor.Desc != query.Desc {
+		return "", nil, repository.ErrInvalidCursor
+	}
+
+	column := sortColumn(query.Sort)
+	if column == "" {
+		if query.Desc {
+			return "companies.id < ?", []interface{}{cursor.ID}, nil
+		}
+		return "companies.id > ?", []interface{}{cursor.ID}, nil
+	}
+
+	var value interface{}
+	switch query.Sort {
+	case repository.CompanySortName:
+		var name string
+		err = json.Unmarshal(cursor.Value, &name)
+		value = name
+	case repository.CompanySortCreatedAt, repository.CompanySortUpdatedAt:
+		var t time.Time
+		err = json.Unmarshal(cursor.Value, &t)
+		value = t
+	case repository.CompanySortJobPostingCount:
+		var count int
+		err = json.Unmarshal(cursor.Value, &count)
+		value = count
+	}
+	if err != nil {
+		return "", nil, repository.ErrInvalidCursor
+	}
+
+	operator := ">"
+	if query.Desc {
+		operator = "<"
+	}
+	return "(" + column + " " + operator + " ? OR (" + column + " = ? AND companies.id > ?))",
+		[]interface{}{value, value, cursor.ID}, nil
+}
@@ -5,9 +5,13 @@ import (
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
+	"github.com/takanoakira/ai-interview-practice/backend/internal/apperror"
 	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
 	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/repository"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/repository/transaction"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/repository/workspace"
 )
 
 type companyRepository struct {
@@ -18,43 +22,76 @@ func NewRepository(db *gorm.DB) repository.CompanyRepository {
 	return &companyRepository{db: db}
 }
 
-func (r *companyRepository) GetCompanies(ctx context.Context, page, limit int) (*entity.CompanyResponse, error) {
-	var companies []entity.Company
-	var total int64
-
-	offset := (page - 1) * limit
+// owned は呼び出し元のワークスペースに属する企業に絞り込んだクエリを返します。
+// 返されたクエリは複数回の実行に再利用できます。
+func (r *companyRepository) owned(ctx context.Context) (*gorm.DB, error) {
+	scope, err := workspace.Companies(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return transaction.Conn(ctx, r.db).Scopes(scope).Session(&gorm.Session{}), nil
+}
 
-	// 総件数を取得
-	if err := r.db.Model(&entity.Company{}).Count(&total).Error; err != nil {
+func (r *companyRepository) GetCompanyByID(ctx context.Context, id int) (*entity.Company, error) {
+	db, err := r.owned(ctx)
+	if err != nil {
 		return nil, err
 	}
 
-	// 企業情報を取得（関連するカスタムフィールドと求人情報も含む）
-	if err := r.db.Preload("CustomFields").
+	var company entity.Company
+	if err := db.
+		Preload("CustomFields").
 		Preload("JobPostings").
 		Preload("JobPostings.CustomFields").
-		Offset(offset).
-		Limit(limit).
-		Find(&companies).Error; err != nil {
+		First(&company, id).Error; err != nil {
+		return nil, apperror.FromDB(err, "company not found")
+	}
+	return &company, nil
+}
+
+func (r *companyRepository) GetCompanyByName(ctx context.Context, name string) (*entity.Company, error) {
+	db, err := r.owned(ctx)
+	if err != nil {
 		return nil, err
 	}
 
-	return &entity.CompanyResponse{
-		Companies: companies,
-		Total:     int(total),
-		Page:      page,
-		Limit:     limit,
-	}, nil
+	var company entity.Company
+	if err := db.Where("companies.name = ?", name).First(&company).Error; err != nil {
+		return nil, apperror.FromDB(err, "company not found")
+	}
+	return &company, nil
+}
+
+func (r *companyRepository) CompanyExists(ctx context.Context, id int) (bool, error) {
+	db, err := r.owned(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	var count int64
+	if err := db.Model(&entity.Company{}).Where("companies.id = ?", id).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
 }
 
 func (r *companyRepository) CreateCompany(ctx context.Context, company *entity.Company) error {
-	return r.db.Create(company).Error
+	if err := workspace.AssignCompany(ctx, company); err != nil {
+		return err
+	}
+	company.Version = 1
+	if err := transaction.Conn(ctx, r.db).Create(company).Error; err != nil {
+		if apperror.IsDuplicateEntry(err) {
+			return repository.ErrDuplicateCompanyName
+		}
+		return apperror.FromDB(err, "")
+	}
+	return nil
 }
 
 func (r *companyRepository) UpdateCompany(ctx context.Context, company *entity.Company) error {
-	// 既存の企業情報を取得
-	var existingCompany entity.Company
-	if err := r.db.First(&existingCompany, company.ID).Error; err != nil {
+	scope, err := workspace.Companies(ctx)
+	if err != nil {
 		return err
 	}
 
@@ -62,37 +99,165 @@ func (r *companyRepository) UpdateCompany(ctx context.Context, company *entity.C
 	updates := map[string]interface{}{
 		"name":                 company.Name,
 		"business_description": company.BusinessDescription,
+		"version":              gorm.Expr("version + 1"),
 		"updated_at":           time.Now(),
 	}
 
 	// トランザクションを開始
-	return r.db.Transaction(func(tx *gorm.DB) error {
+	err = transaction.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		// 行ロックを取得してバージョンを確認する（他のワークスペースの企業は存在しないものとして扱う）
+		if err := lockVersion(tx, scope, company.ID, company.Version); err != nil {
+			return err
+		}
+
 		// 企業情報を更新
 		if err := tx.Model(&entity.Company{}).Where("id = ?", company.ID).Updates(updates).Error; err != nil {
 			return err
 		}
 
-		// 既存のカスタムフィールドを削除
-		if err := tx.Where("company_id = ?", company.ID).Delete(&entity.CompanyCustomField{}).Error; err != nil {
+		// カスタムフィールドを ID ごとに追加・更新・削除する
+		return syncCustomFields(tx, company.ID, company.CustomFields)
+	})
+	if apperror.IsDuplicateEntry(err) {
+		return repository.ErrDuplicateCompanyName
+	}
+	return apperror.FromDB(err, "company not found")
+}
+
+// lockVersion は企業の行ロックを取得し、version が 0 でなければ現在のバージョンと一致することを確認します。
+// 一致しない場合は ErrVersionMismatch を返します。
+func lockVersion(tx *gorm.DB, scope func(*gorm.DB) *gorm.DB, id, version int) error {
+	var current entity.Company
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Scopes(scope).
+		Select("companies.id", "companies.version").
+		First(&current, id).Error; err != nil {
+		return err
+	}
+	if version != 0 && version != current.Version {
+		return repository.ErrVersionMismatch
+	}
+	return nil
+}
+
+// syncCustomFields は企業のカスタムフィールドを fields に一致させます。
+// ID が 0 の項目は追加、ID を持つ項目は内容が変わった場合のみ更新し、fields に含まれない既存の項目は削除します。
+// 企業に属さない ID が含まれる場合は ErrCustomFieldNotFound を返します。
+func syncCustomFields(tx *gorm.DB, companyID int, fields []entity.CompanyCustomField) error {
+	var existing []entity.CompanyCustomField
+	if err := tx.Where("company_id = ?", companyID).Find(&existing).Error; err != nil {
+		return err
+	}
+	current := make(map[int]entity.CompanyCustomField, len(existing))
+	for _, field := range existing {
+		current[field.ID] = field
+	}
+
+	now := time.Now()
+	kept := make(map[int]bool, len(fields))
+	var added []entity.CompanyCustomField
+	for _, field := range fields {
+		if field.ID == 0 {
+			field.CompanyID = companyID
+			field.CreatedAt = now
+			field.UpdatedAt = now
+			added = append(added, field)
+			continue
+		}
+		before, ok := current[field.ID]
+		if !ok || kept[field.ID] {
+			return repository.ErrCustomFieldNotFound
+		}
+		kept[field.ID] = true
+		if before.FieldName == field.FieldName && before.Content == field.Content {
+			continue
+		}
+		if err := tx.Model(&entity.CompanyCustomField{}).Where("id = ?", field.ID).Updates(map[string]interface{}{
+			"field_name": field.FieldName,
+			"content":    field.Content,
+			"updated_at": now,
+		}).Error; err != nil {
 			return err
 		}
+	}
 
-		// 新しいカスタムフィールドを作成
-		if len(company.CustomFields) > 0 {
-			for i := range company.CustomFields {
-				company.CustomFields[i].CompanyID = company.ID
-				company.CustomFields[i].CreatedAt = time.Now()
-				company.CustomFields[i].UpdatedAt = time.Now()
-			}
-			if err := tx.Create(&company.CustomFields).Error; err != nil {
-				return err
-			}
+	var removed []int
+	for _, field := range existing {
+		if !kept[field.ID] {
+			removed = append(removed, field.ID)
 		}
+	}
+	if len(removed) > 0 {
+		if err := tx.Where("company_id = ? AND id IN ?", companyID, removed).Delete(&entity.CompanyCustomField{}).Error; err != nil {
+			return err
+		}
+	}
+	if len(added) > 0 {
+		return tx.Create(&added).Error
+	}
+	return nil
+}
+
+func (r *companyRepository) DeleteCompany(ctx context.Context, id, version int) error {
+	scope, err := workspace.Companies(ctx)
+	if err != nil {
+		return err
+	}
 
-		return nil
+	err = transaction.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := lockVersion(tx, scope, id, version); err != nil {
+			return err
+		}
+		// ゴミ箱に移動する（求人情報・追加情報は完全に削除するまで残し、企業と共に非表示になる）
+		return tx.Delete(&entity.Company{}, id).Error
 	})
+	return apperror.FromDB(err, "company not found")
+}
+
+func (r *companyRepository) ListDeletedCompanies(ctx context.Context) ([]entity.Company, error) {
+	db, err := r.owned(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var companies []entity.Company
+	if err := db.Unscoped().
+		Preload("CustomFields").
+		Where("companies.deleted_at IS NOT NULL").
+		Order("companies.deleted_at DESC, companies.id DESC").
+		Find(&companies).Error; err != nil {
+		return nil, err
+	}
+	return companies, nil
+}
+
+func (r *companyRepository) RestoreCompany(ctx context.Context, id int) (*entity.Company, error) {
+	db, err := r.owned(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := db.Unscoped().Model(&entity.Company{}).
+		Where("companies.id = ? AND companies.deleted_at IS NOT NULL", id).
+		Updates(map[string]interface{}{
+			"deleted_at": nil,
+			"version":    gorm.Expr("version + 1"),
+		})
+	if apperror.IsDuplicateEntry(result.Error) {
+		// ゴミ箱に移動した後に同名の企業が作成された
+		return nil, repository.ErrDuplicateCompanyName
+	}
+	if result.Error != nil {
+		return nil, apperror.FromDB(result.Error, "company not found")
+	}
+	if result.RowsAffected == 0 {
+		return nil, apperror.FromDB(gorm.ErrRecordNotFound, "deleted company not found")
+	}
+	return r.GetCompanyByID(ctx, id)
 }
 
-func (r *companyRepository) DeleteCompany(ctx context.Context, id int) error {
-	return r.db.Delete(&entity.Company{}, id).Error
+func (r *companyRepository) PurgeDeletedCompanies(ctx context.Context, before time.Time) (int64, error) {
+	// 求人情報・追加情報は外部キー制約で削除され、面接セッションの参照は NULL になる
+	result := transaction.Conn(ctx, r.db).Unscoped().Where("deleted_at < ?", before).Delete(&entity.Company{})
+	return result.RowsAffected, result.Error
 }
@@ -0,0 +1,110 @@
+package company_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gorm.io/gorm"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/repository"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/repository/company"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/repository/repositorytest"
+)
+
+const (
+	userA        = 1
+	userB        = 2
+	organization = 10
+)
+
+// otherWorkspaces は userA の個人のワークスペースの企業を参照できないワークスペースです
+var otherWorkspaces = map[string]context.Context{
+	"other user":             repositorytest.UserContext(userB),
+	"same user in org":       repositorytest.MemberContext(userA, organization, entity.MembershipRoleOwner),
+	"other user in same org": repositorytest.MemberContext(userB, organization, entity.MembershipRoleAdmin),
+}
+
+func createCompany(t *testing.T, repo repository.CompanyRepository, ctx context.Context, name string) *entity.Company {
+	t.Helper()
+	c := &entity.Company{Name: name, CustomFields: []entity.CompanyCustomField{{FieldName: "社風", Content: "自由"}}}
+	if err := repo.CreateCompany(ctx, c); err != nil {
+		t.Fatalf("CreateCompany(%q): %v", name, err)
+	}
+	return c
+}
+
+func TestGetCompaniesListsOnlyOwnWorkspace(t *testing.T) {
+	repo := company.NewRepository(repositorytest.NewDB(t))
+	ctxA := repositorytest.UserContext(userA)
+	ctxB := repositorytest.UserContext(userB)
+	ctxOrg := repositorytest.MemberContext(userA, organization, entity.MembershipRoleOwner)
+
+	createCompany(t, repo, ctxA, "A社")
+	createCompany(t, repo, ctxB, "B社")
+	createCompany(t, repo, ctxOrg, "組織の企業")
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want []string
+	}{
+		{"user A", ctxA, []string{"A社"}},
+		{"user B", ctxB, []string{"B社"}},
+		{"organization", ctxOrg, []string{"組織の企業"}},
+		{"other member of organization", repositorytest.MemberContext(userB, organization, entity.MembershipRoleCandidate), []string{"組織の企業"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.GetCompanies(tt.ctx, repository.CompanyQuery{Page: 1, Limit: 10})
+			if err != nil {
+				t.Fatalf("GetCompanies: %v", err)
+			}
+			var names []string
+			for _, c := range got.Companies {
+				names = append(names, c.Name)
+			}
+			if len(names) != len(tt.want) || (len(names) > 0 && names[0] != tt.want[0]) {
+				t.Errorf("GetCompanies = %v, want %v", names, tt.want)
+			}
+			if got.Total == nil || *got.Total != len(tt.want) {
+				t.Errorf("Total = %v, want %d", got.Total, len(tt.want))
+			}
+		})
+	}
+}
+
+func TestCompanyIsNotFoundFromOtherWorkspaces(t *testing.T) {
+	for name, ctx := range otherWorkspaces {
+		t.Run(name, func(t *testing.T) {
+			repo := company.NewRepository(repositorytest.NewDB(t))
+			ctxA := repositorytest.UserContext(userA)
+			owned := createCompany(t, repo, ctxA, "A社")
+
+			if _, err := repo.GetCompanyByID(ctx, owned.ID); !errors.Is(err, gorm.ErrRecordNotFound) {
+				t.Errorf("GetCompanyByID error = %v, want ErrRecordNotFound", err)
+			}
+			if exists, err := repo.CompanyExists(ctx, owned.ID); err != nil || exists {
+				t.Errorf("CompanyExists = %v, %v, want false", exists, err)
+			}
+
+			update := &entity.Company{ID: owned.ID, Name: "乗っ取り", Version: owned.Version}
+			if err := repo.UpdateCompany(ctx, update); !errors.Is(err, gorm.ErrRecordNotFound) {
+				t.Errorf("UpdateCompany error = %v, want ErrRecordNotFound", err)
+			}
+			if err := repo.DeleteCompany(ctx, owned.ID, 0); !errors.Is(err, gorm.ErrRecordNotFound) {
+				t.Errorf("DeleteCompany error = %v, want ErrRecordNotFound", err)
+			}
+
+			// 所有者からは変更されずに参照できる
+			got, err := repo.GetCompanyByID(ctxA, owned.ID)
+			if err != nil {
+				t.Fatalf("GetCompanyByID by owner: %v", err)
+			}
+			if got.Name != "A社" || got.Version != owned.Version || len(got.CustomFields) != 1 {
+				t.Errorf("company was modified: name=%q version=%d custom_fields=%d", got.Name, got.Version, len(got.CustomFields))
+			}
+		})
+	}
+}
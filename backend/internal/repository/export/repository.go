@@ -0,0 +1,141 @@
+package export
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/repository"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/repository/transaction"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/repository/workspace"
+)
+
+type exportRepository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) repository.ExportRepository {
+	return &exportRepository{db: db}
+}
+
+// each は query の結果を1行ずつ T に読み込んで fn に渡します
+func each[T any](query *gorm.DB, fn func(*T) error) error {
+	rows, err := query.Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var record T
+		if err := query.ScanRows(rows, &record); err != nil {
+			return err
+		}
+		if err := fn(&record); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// companyIDs は呼び出し元のワークスペースの企業（ゴミ箱にあるものを除く）の ID のサブクエリを返します
+func (r *exportRepository) companyIDs(ctx context.Context) (*gorm.DB, error) {
+	scope, err := workspace.Companies(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.db.Model(&entity.Company{}).Select("companies.id").Scopes(scope), nil
+}
+
+// jobPostingIDs はワークスペースの求人情報（ゴミ箱にあるものを除く）の ID のサブクエリを返します
+func (r *exportRepository) jobPostingIDs(ctx context.Context) (*gorm.DB, error) {
+	companyIDs, err := r.companyIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.db.Model(&entity.JobPosting{}).Select("job_postings.id").Where("job_postings.company_id IN (?)", companyIDs), nil
+}
+
+// sessionIDs は呼び出し元が作成した面接セッションの ID のサブクエリを返します。
+// 組織のワークスペースでも他のメンバーのセッションは含まず、企業に紐付かないセッションも含みます。
+func (r *exportRepository) sessionIDs(ctx context.Context) (*gorm.DB, error) {
+	scope, err := workspace.Sessions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.db.Table("interview_sessions").Select("interview_sessions.id").Scopes(scope), nil
+}
+
+func (r *exportRepository) EachCompany(ctx context.Context, fn func(*entity.ExportCompany) error) error {
+	companyIDs, err := r.companyIDs(ctx)
+	if err != nil {
+		return err
+	}
+	return each(transaction.Conn(ctx, r.db).Table("companies").Where("id IN (?)", companyIDs).Order("id"), fn)
+}
+
+func (r *exportRepository) EachCompanyCustomField(ctx context.Context, fn func(*entity.ExportCompanyCustomField) error) error {
+	companyIDs, err := r.companyIDs(ctx)
+	if err != nil {
+		return err
+	}
+	return each(transaction.Conn(ctx, r.db).Table("company_custom_fields").Where("company_id IN (?)", companyIDs).Order("id"), fn)
+}
+
+func (r *exportRepository) EachJobPosting(ctx context.Context, fn func(*entity.ExportJobPosting) error) error {
+	jobPostingIDs, err := r.jobPostingIDs(ctx)
+	if err != nil {
+		return err
+	}
+	return each(transaction.Conn(ctx, r.db).Table("job_postings").Where("id IN (?)", jobPostingIDs).Order("id"), fn)
+}
+
+func (r *exportRepository) EachJobCustomField(ctx context.Context, fn func(*entity.ExportJobCustomField) error) error {
+	jobPostingIDs, err := r.jobPostingIDs(ctx)
+	if err != nil {
+		return err
+	}
+	return each(transaction.Conn(ctx, r.db).Table("job_custom_fields").Where("job_id IN (?)", jobPostingIDs).Order("id"), fn)
+}
+
+func (r *exportRepository) EachInterviewSession(ctx context.Context, fn func(*entity.ExportInterviewSession) error) error {
+	sessionIDs, err := r.sessionIDs(ctx)
+	if err != nil {
+		return err
+	}
+	return each(transaction.Conn(ctx, r.db).Table("interview_sessions").Where("id IN (?)", sessionIDs).Order("id"), fn)
+}
+
+func (r *exportRepository) EachInterviewQuestion(ctx context.Context, fn func(*entity.ExportInterviewQuestion) error) error {
+	sessionIDs, err := r.sessionIDs(ctx)
+	if err != nil {
+		return err
+	}
+	return each(transaction.Conn(ctx, r.db).Table("interview_questions").Where("session_id IN (?)", sessionIDs).Order("id"), fn)
+}
+
+func (r *exportRepository) EachInterviewAnswer(ctx context.Context, fn func(*entity.ExportInterviewAnswer) error) error {
+	sessionIDs, err := r.sessionIDs(ctx)
+	if err != nil {
+		return err
+	}
+	questionIDs := r.db.Table("interview_questions").Select("id").Where("session_id IN (?)", sessionIDs)
+	return each(transaction.Conn(ctx, r.db).Table("interview_answers").Where("question_id IN (?)", questionIDs).Order("id"), fn)
+}
+
+func (r *exportRepository) EachInterviewEvaluation(ctx context.Context, fn func(*entity.ExportInterviewEvaluation) error) error {
+	sessionIDs, err := r.sessionIDs(ctx)
+	if err != nil {
+		return err
+	}
+	return each(transaction.Conn(ctx, r.db).Table("interview_evaluations").Where("session_id IN (?)", sessionIDs).Order("id"), fn)
+}
+
+func (r *exportRepository) EachAnswerEvaluation(ctx context.Context, fn func(*entity.ExportAnswerEvaluation) error) error {
+	sessionIDs, err := r.sessionIDs(ctx)
+	if err != nil {
+		return err
+	}
+	return each(transaction.Conn(ctx, r.db).Table("answer_evaluations").Where("session_id IN (?)", sessionIDs).Order("id"), fn)
+}
@@ -0,0 +1,81 @@
+package interview_evaluation
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/apperror"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/repository"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/repository/workspace"
+)
+
+type interviewEvaluationRepository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) repository.InterviewEvaluationRepository {
+	return &interviewEvaluationRepository{db: db}
+}
+
+func (r *interviewEvaluationRepository) GetEvaluationBySession(ctx context.Context, sessionID int) (*entity.InterviewEvaluation, error) {
+	scope, err := workspace.Sessions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sessionIDs := r.db.Model(&entity.InterviewSession{}).Select("interview_sessions.id").Scopes(scope)
+
+	// 他のユーザーのセッションの評価は存在しないものとして扱う
+	var evaluation entity.InterviewEvaluation
+	if err := r.db.WithContext(ctx).
+		Preload("AnswerEvaluations", func(db *gorm.DB) *gorm.DB {
+			return db.Order("question_id")
+		}).
+		Where("session_id = ? AND session_id IN (?)", sessionID, sessionIDs).
+		First(&evaluation).Error; err != nil {
+		return nil, apperror.FromDB(err, "interview evaluation not found")
+	}
+	return &evaluation, nil
+}
+
+func (r *interviewEvaluationRepository) SaveEvaluation(ctx context.Context, evaluation *entity.InterviewEvaluation) error {
+	scope, err := workspace.Sessions(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// フィードバック完了としてセッションを CLOSING に遷移させる（他のユーザーのセッションは存在しないものとして扱う）
+		var session entity.InterviewSession
+		if err := tx.Scopes(scope).Select("interview_sessions.id").First(&session, evaluation.SessionID).Error; err != nil {
+			return err
+		}
+		result := tx.Model(&entity.InterviewSession{}).
+			Where("id = ? AND status = ?", evaluation.SessionID, entity.InterviewSessionStatusCompleted).
+			Update("status", entity.InterviewSessionStatusClosing)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return repository.ErrSessionStatusConflict
+		}
+
+		now := time.Now()
+		evaluation.CreatedAt = now
+		for i := range evaluation.AnswerEvaluations {
+			evaluation.AnswerEvaluations[i].SessionID = evaluation.SessionID
+			evaluation.AnswerEvaluations[i].CreatedAt = now
+		}
+
+		if len(evaluation.AnswerEvaluations) > 0 {
+			if err := tx.Create(&evaluation.AnswerEvaluations).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Omit("AnswerEvaluations").Create(evaluation).Error
+	})
+	return apperror.FromDB(err, "interview session not found")
+}
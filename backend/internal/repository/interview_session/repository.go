@@ -0,0 +1,153 @@
+package interview_session
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/apperror"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/repository"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/repository/transaction"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/repository/workspace"
+)
+
+type interviewSessionRepository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) repository.InterviewSessionRepository {
+	return &interviewSessionRepository{db: db}
+}
+
+func (r *interviewSessionRepository) CreateSession(ctx context.Context, session *entity.InterviewSession) error {
+	if err := workspace.AssignSession(ctx, session); err != nil {
+		return err
+	}
+	return apperror.FromDB(r.db.WithContext(ctx).Omit("Questions").Create(session).Error, "")
+}
+
+func (r *interviewSessionRepository) GetSession(ctx context.Context, id int) (*entity.InterviewSession, error) {
+	scope, err := workspace.Sessions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// 他のユーザーのセッションは存在しないものとして扱う
+	var session entity.InterviewSession
+	if err := r.db.WithContext(ctx).
+		Scopes(scope).
+		Preload("Questions", func(db *gorm.DB) *gorm.DB {
+			return db.Order("sequence")
+		}).
+		Preload("Questions.Answer").
+		First(&session, id).Error; err != nil {
+		return nil, apperror.FromDB(err, "interview session not found")
+	}
+	return &session, nil
+}
+
+func (r *interviewSessionRepository) AdvanceSession(ctx context.Context, session *entity.InterviewSession, from entity.InterviewSessionStatus, answer *entity.InterviewAnswer, question *entity.InterviewQuestion) error {
+	scope, err := workspace.Sessions(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 行ロックを取得してステータスを確認し、同時リクエストによる二重遷移を防ぐ（他のユーザーのセッションは存在しないものとして扱う）
+		var current entity.InterviewSession
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Scopes(scope).
+			Select("id", "status").
+			First(&current, session.ID).Error; err != nil {
+			return err
+		}
+		if current.Status != from {
+			return repository.ErrSessionStatusConflict
+		}
+
+		if err := tx.Model(&entity.InterviewSession{}).
+			Where("id = ?", session.ID).
+			Updates(map[string]interface{}{
+				"status":   session.Status,
+				"ended_at": session.EndedAt,
+			}).Error; err != nil {
+			return err
+		}
+
+		// 前の質問への回答を保存
+		if answer != nil {
+			answer.CreatedAt = time.Now()
+			if err := tx.Create(answer).Error; err != nil {
+				return err
+			}
+		}
+
+		// 次の質問を保存
+		if question != nil {
+			question.SessionID = session.ID
+			question.CreatedAt = time.Now()
+			if err := tx.Omit("Answer").Create(question).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	return apperror.FromDB(err, "interview session not found")
+}
+
+func (r *interviewSessionRepository) ImportSession(ctx context.Context, session *entity.InterviewSession, evaluation *entity.InterviewEvaluation) error {
+	if err := workspace.AssignSession(ctx, session); err != nil {
+		return err
+	}
+
+	// インポートのトランザクション内ではセーブポイントになる
+	err := transaction.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		session.ID = 0
+		if err := tx.Omit("Questions").Create(session).Error; err != nil {
+			return err
+		}
+
+		questionIDs := make(map[int]int, len(session.Questions))
+		for i := range session.Questions {
+			question := &session.Questions[i]
+			sourceID := question.ID
+			question.ID = 0
+			question.SessionID = session.ID
+			if err := tx.Omit("Answer").Create(question).Error; err != nil {
+				return err
+			}
+			questionIDs[sourceID] = question.ID
+
+			if question.Answer != nil {
+				question.Answer.ID = 0
+				question.Answer.QuestionID = question.ID
+				if err := tx.Create(question.Answer).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		if evaluation == nil {
+			return nil
+		}
+		evaluation.ID = 0
+		evaluation.SessionID = session.ID
+		for i := range evaluation.AnswerEvaluations {
+			answer := &evaluation.AnswerEvaluations[i]
+			answer.ID = 0
+			answer.SessionID = session.ID
+			answer.QuestionID = questionIDs[answer.QuestionID]
+		}
+		if len(evaluation.AnswerEvaluations) > 0 {
+			if err := tx.Create(&evaluation.AnswerEvaluations).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Omit("AnswerEvaluations").Create(evaluation).Error
+	})
+	return apperror.FromDB(err, "")
+}
@@ -0,0 +1,85 @@
+package interview_session_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/repository/interview_evaluation"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/repository/interview_session"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/repository/repositorytest"
+)
+
+const (
+	userA = 1
+	userB = 2
+)
+
+func TestImportSessionRemapsIDs(t *testing.T) {
+	db := repositorytest.NewDB(t)
+	repo := interview_session.NewRepository(db)
+	ctx := repositorytest.UserContext(userA)
+
+	// 既存のセッションがあるため、アーカイブでの ID（1・2）と新しい ID は一致しない
+	existing := &entity.InterviewSession{QuestionCount: 1, Status: entity.InterviewSessionStatusCreated, StartedAt: time.Now()}
+	if err := repo.CreateSession(repositorytest.UserContext(userB), existing); err != nil {
+		t.Fatalf("CreateSession: %v", err)
+	}
+	if err := repo.AdvanceSession(repositorytest.UserContext(userB), existing, entity.InterviewSessionStatusCreated, nil,
+		&entity.InterviewQuestion{Content: "既存の質問", Sequence: 1}); err != nil {
+		t.Fatalf("AdvanceSession: %v", err)
+	}
+
+	startedAt := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	session := &entity.InterviewSession{
+		ID:            1,
+		QuestionCount: 2,
+		Status:        entity.InterviewSessionStatusClosing,
+		StartedAt:     startedAt,
+		Questions: []entity.InterviewQuestion{
+			{ID: 1, Content: "自己紹介をお願いします", Sequence: 1, Answer: &entity.InterviewAnswer{Content: "回答1"}},
+			{ID: 2, Content: "志望動機を教えてください", Sequence: 2, Answer: &entity.InterviewAnswer{Content: "回答2"}},
+		},
+	}
+	evaluation := &entity.InterviewEvaluation{
+		TotalRank:      entity.EvaluationRankB,
+		OverallComment: "良い",
+		AnswerEvaluations: []entity.AnswerEvaluation{
+			{QuestionID: 2, QuestionComment: "志望動機の評価"},
+			{QuestionID: 1, QuestionComment: "自己紹介の評価"},
+		},
+	}
+	if err := repo.ImportSession(ctx, session, evaluation); err != nil {
+		t.Fatalf("ImportSession: %v", err)
+	}
+	if session.ID == 1 || session.OwnerUserID != userA {
+		t.Errorf("session id = %d owner = %d, want a new id owned by user A", session.ID, session.OwnerUserID)
+	}
+
+	got, err := repo.GetSession(ctx, session.ID)
+	if err != nil {
+		t.Fatalf("GetSession: %v", err)
+	}
+	if got.Status != entity.InterviewSessionStatusClosing || !got.StartedAt.Equal(startedAt) || len(got.Questions) != 2 {
+		t.Fatalf("session = status %s started_at %v questions %d, want CLOSING %v 2", got.Status, got.StartedAt, len(got.Questions), startedAt)
+	}
+	questionIDs := map[string]int{}
+	for _, q := range got.Questions {
+		if q.Answer == nil || q.Answer.QuestionID != q.ID {
+			t.Errorf("question %q answer = %+v, want answer of question %d", q.Content, q.Answer, q.ID)
+		}
+		questionIDs[q.Content] = q.ID
+	}
+
+	saved, err := interview_evaluation.NewRepository(db).GetEvaluationBySession(ctx, session.ID)
+	if err != nil {
+		t.Fatalf("GetEvaluationBySession: %v", err)
+	}
+	comments := map[int]string{}
+	for _, a := range saved.AnswerEvaluations {
+		comments[a.QuestionID] = a.QuestionComment
+	}
+	if comments[questionIDs["自己紹介をお願いします"]] != "自己紹介の評価" || comments[questionIDs["志望動機を教えてください"]] != "志望動機の評価" {
+		t.Errorf("answer evaluations by question = %v, want them attached to the imported questions %v", comments, questionIDs)
+	}
+}
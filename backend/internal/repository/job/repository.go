@@ -0,0 +1,137 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/apperror"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/repository"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/repository/workspace"
+)
+
+type jobRepository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) repository.JobRepository {
+	return &jobRepository{db: db}
+}
+
+func (r *jobRepository) CreateJob(ctx context.Context, job *entity.Job) error {
+	if err := workspace.AssignJob(ctx, job); err != nil {
+		return err
+	}
+	return r.db.WithContext(ctx).Create(job).Error
+}
+
+func (r *jobRepository) GetJob(ctx context.Context, id int) (*entity.Job, error) {
+	scope, err := workspace.Jobs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// 他のユーザーが登録したジョブは存在しないものとして扱う
+	var job entity.Job
+	if err := r.db.WithContext(ctx).Scopes(scope).First(&job, id).Error; err != nil {
+		return nil, apperror.FromDB(err, "job not found")
+	}
+	return &job, nil
+}
+
+func (r *jobRepository) ClaimNextJob(ctx context.Context, workerID string, now, leaseExpiresAt time.Time) (*entity.Job, error) {
+	var job entity.Job
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 他のワーカーが取得中の行はスキップする
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND run_at <= ?", entity.JobStatusPending, now).
+			Order("run_at, id").
+			First(&job).Error; err != nil {
+			return err
+		}
+
+		job.Status = entity.JobStatusRunning
+		job.Attempts++
+		job.StartedAt = &now
+		job.LockedBy = &workerID
+		job.LeaseExpiresAt = &leaseExpiresAt
+		return tx.Model(&entity.Job{}).Where("id = ?", job.ID).Updates(map[string]interface{}{
+			"status":           job.Status,
+			"attempts":         job.Attempts,
+			"started_at":       job.StartedAt,
+			"locked_by":        job.LockedBy,
+			"lease_expires_at": job.LeaseExpiresAt,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *jobRepository) ExtendLease(ctx context.Context, id int, workerID string, leaseExpiresAt time.Time) error {
+	return r.updateLeased(ctx, id, workerID, map[string]interface{}{
+		"lease_expires_at": leaseExpiresAt,
+	})
+}
+
+func (r *jobRepository) MarkSucceeded(ctx context.Context, id int, workerID string, result json.RawMessage) error {
+	return r.updateLeased(ctx, id, workerID, map[string]interface{}{
+		"status":           entity.JobStatusSucceeded,
+		"result":           result,
+		"finished_at":      time.Now(),
+		"locked_by":        nil,
+		"lease_expires_at": nil,
+	})
+}
+
+func (r *jobRepository) MarkFailed(ctx context.Context, id int, workerID string, lastError string) error {
+	return r.updateLeased(ctx, id, workerID, map[string]interface{}{
+		"status":           entity.JobStatusFailed,
+		"last_error":       lastError,
+		"finished_at":      time.Now(),
+		"locked_by":        nil,
+		"lease_expires_at": nil,
+	})
+}
+
+func (r *jobRepository) Reschedule(ctx context.Context, id int, workerID string, lastError string, runAt time.Time) error {
+	return r.updateLeased(ctx, id, workerID, map[string]interface{}{
+		"status":           entity.JobStatusPending,
+		"last_error":       lastError,
+		"run_at":           runAt,
+		"locked_by":        nil,
+		"lease_expires_at": nil,
+	})
+}
+
+// updateLeased は workerID がリースを保持している running のジョブを更新します
+func (r *jobRepository) updateLeased(ctx context.Context, id int, workerID string, values map[string]interface{}) error {
+	result := r.db.WithContext(ctx).Model(&entity.Job{}).
+		Where("id = ? AND status = ? AND locked_by = ?", id, entity.JobStatusRunning, workerID).
+		Updates(values)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *jobRepository) RequeueExpiredJobs(ctx context.Context, now time.Time) (int64, error) {
+	// リース期限のない running のジョブはリース導入前に実行されていたもので、実行中のワーカーは存在しない
+	result := r.db.WithContext(ctx).Model(&entity.Job{}).
+		Where("status = ? AND (lease_expires_at IS NULL OR lease_expires_at < ?)", entity.JobStatusRunning, now).
+		Updates(map[string]interface{}{
+			"status":           entity.JobStatusPending,
+			"run_at":           now,
+			"locked_by":        nil,
+			"lease_expires_at": nil,
+		})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,116 @@
+package job_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/repository"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/repository/job"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/repository/repositorytest"
+)
+
+const (
+	userA   = 1
+	workerA = "worker-a"
+	workerB = "worker-b"
+)
+
+func createJob(t *testing.T, repo repository.JobRepository, ctx context.Context, runAt time.Time) *entity.Job {
+	t.Helper()
+	j := &entity.Job{
+		Type:           "test",
+		Payload:        json.RawMessage(`{}`),
+		Status:         entity.JobStatusPending,
+		MaxAttempts:    3,
+		TimeoutSeconds: 30,
+		RunAt:          runAt,
+	}
+	if err := repo.CreateJob(ctx, j); err != nil {
+		t.Fatalf("CreateJob: %v", err)
+	}
+	return j
+}
+
+func TestRequeueExpiredJobsKeepsLiveLeases(t *testing.T) {
+	repo := job.NewRepository(repositorytest.NewDB(t))
+	ctx := repositorytest.UserContext(userA)
+	now := time.Now()
+
+	createJob(t, repo, ctx, now.Add(-2*time.Second))
+	createJob(t, repo, ctx, now.Add(-time.Second))
+
+	// workerA のリースは期限切れ、workerB のリースは有効
+	expired, err := repo.ClaimNextJob(ctx, workerA, now, now.Add(-time.Millisecond))
+	if err != nil {
+		t.Fatalf("ClaimNextJob(workerA): %v", err)
+	}
+	live, err := repo.ClaimNextJob(ctx, workerB, now, now.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("ClaimNextJob(workerB): %v", err)
+	}
+
+	requeued, err := repo.RequeueExpiredJobs(ctx, now)
+	if err != nil {
+		t.Fatalf("RequeueExpiredJobs: %v", err)
+	}
+	if requeued != 1 {
+		t.Errorf("requeued = %d, want 1", requeued)
+	}
+
+	if got, err := repo.GetJob(ctx, expired.ID); err != nil || got.Status != entity.JobStatusPending || got.LockedBy != nil {
+		t.Errorf("expired job = %+v, %v, want pending without owner", got, err)
+	}
+	if got, err := repo.GetJob(ctx, live.ID); err != nil || got.Status != entity.JobStatusRunning || got.LockedBy == nil || *got.LockedBy != workerB {
+		t.Errorf("live job = %+v, %v, want running by %s", got, err, workerB)
+	}
+}
+
+func TestLostLeaseCannotBeUpdated(t *testing.T) {
+	repo := job.NewRepository(repositorytest.NewDB(t))
+	ctx := repositorytest.UserContext(userA)
+	now := time.Now()
+
+	createJob(t, repo, ctx, now.Add(-time.Second))
+	claimed, err := repo.ClaimNextJob(ctx, workerA, now, now.Add(-time.Millisecond))
+	if err != nil {
+		t.Fatalf("ClaimNextJob(workerA): %v", err)
+	}
+	if _, err := repo.RequeueExpiredJobs(ctx, now); err != nil {
+		t.Fatalf("RequeueExpiredJobs: %v", err)
+	}
+	reclaimed, err := repo.ClaimNextJob(ctx, workerB, now, now.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("ClaimNextJob(workerB): %v", err)
+	}
+	if reclaimed.ID != claimed.ID || reclaimed.Attempts != 2 {
+		t.Fatalf("reclaimed = id %d attempts %d, want id %d attempts 2", reclaimed.ID, reclaimed.Attempts, claimed.ID)
+	}
+
+	// リースを失った workerA はジョブを延長・完了できない
+	if err := repo.ExtendLease(ctx, claimed.ID, workerA, now.Add(time.Minute)); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("ExtendLease by workerA error = %v, want ErrRecordNotFound", err)
+	}
+	if err := repo.MarkSucceeded(ctx, claimed.ID, workerA, json.RawMessage(`{}`)); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("MarkSucceeded by workerA error = %v, want ErrRecordNotFound", err)
+	}
+
+	if err := repo.ExtendLease(ctx, claimed.ID, workerB, now.Add(2*time.Minute)); err != nil {
+		t.Errorf("ExtendLease by workerB: %v", err)
+	}
+	if err := repo.MarkSucceeded(ctx, claimed.ID, workerB, json.RawMessage(`{"ok":true}`)); err != nil {
+		t.Fatalf("MarkSucceeded by workerB: %v", err)
+	}
+	got, err := repo.GetJob(ctx, claimed.ID)
+	if err != nil {
+		t.Fatalf("GetJob: %v", err)
+	}
+	if got.Status != entity.JobStatusSucceeded || got.LockedBy != nil || got.LeaseExpiresAt != nil {
+		t.Errorf("job = status %s locked_by %v lease %v, want succeeded without lease", got.Status, got.LockedBy, got.LeaseExpiresAt)
+	}
+}
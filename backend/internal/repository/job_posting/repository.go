@@ -5,9 +5,13 @@ import (
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
+	"github.com/takanoakira/ai-interview-practice/backend/internal/apperror"
 	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
 	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/repository"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/repository/transaction"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/repository/workspace"
 )
 
 type jobPostingRepository struct {
@@ -18,69 +22,384 @@ func NewRepository(db *gorm.DB) repository.JobPostingRepository {
 	return &jobPostingRepository{db: db}
 }
 
+// owned は呼び出し元のワークスペースに属する求人情報に絞り込んだクエリを返します。
+// 求人情報は企業の所属を引き継ぎます。返されたクエリは複数回の実行に再利用できます。
+func (r *jobPostingRepository) owned(ctx context.Context) (*gorm.DB, error) {
+	scope, err := r.ownedScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return transaction.Conn(ctx, r.db).Scopes(scope).Session(&gorm.Session{}), nil
+}
+
+// ownedScope は求人情報を呼び出し元のワークスペースに属する企業のものに絞り込むスコープを返します
+func (r *jobPostingRepository) ownedScope(ctx context.Context) (func(*gorm.DB) *gorm.DB, error) {
+	scope, err := workspace.Companies(ctx)
+	if err != nil {
+		return nil, err
+	}
+	companyIDs := r.db.Model(&entity.Company{}).Select("companies.id").Scopes(scope)
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("job_postings.company_id IN (?)", companyIDs)
+	}, nil
+}
+
+// ensureCompanyOwned は企業が呼び出し元のワークスペースに属することを確認します。
+// 存在しない場合・他のワークスペースの企業の場合は gorm.ErrRecordNotFound を返します。
+func (r *jobPostingRepository) ensureCompanyOwned(ctx context.Context, companyID int) error {
+	scope, err := workspace.Companies(ctx)
+	if err != nil {
+		return err
+	}
+	err = transaction.Conn(ctx, r.db).Select("companies.id").Scopes(scope).First(&entity.Company{}, companyID).Error
+	return apperror.FromDB(err, "company not found")
+}
+
+func (r *jobPostingRepository) GetJobPosting(ctx context.Context, id int) (*entity.JobPosting, error) {
+	db, err := r.owned(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var jobPosting entity.JobPosting
+	if err := db.Preload("CustomFields").First(&jobPosting, id).Error; err != nil {
+		return nil, apperror.FromDB(err, "job posting not found")
+	}
+	return &jobPosting, nil
+}
+
+func (r *jobPostingRepository) ListJobPostingsByCompany(ctx context.Context, companyID, page, limit int) (*entity.JobPostingResponse, error) {
+	// 企業が存在しない場合・他のワークスペースの企業の場合は gorm.ErrRecordNotFound を返す
+	if err := r.ensureCompanyOwned(ctx, companyID); err != nil {
+		return nil, err
+	}
+
+	var jobPostings []entity.JobPosting
+	var total int64
+
+	offset := (page - 1) * limit
+
+	// 総件数を取得
+	if err := transaction.Conn(ctx, r.db).Model(&entity.JobPosting{}).Where("company_id = ?", companyID).Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	// 求人情報を取得（関連するカスタムフィールドも含む）
+	if err := transaction.Conn(ctx, r.db).
+		Preload("CustomFields").
+		Where("company_id = ?", companyID).
+		Order("id").
+		Offset(offset).
+		Limit(limit).
+		Find(&jobPostings).Error; err != nil {
+		return nil, err
+	}
+
+	return &entity.JobPostingResponse{
+		JobPostings: jobPostings,
+		Total:       int(total),
+		Page:        page,
+		Limit:       limit,
+	}, nil
+}
+
 func (r *jobPostingRepository) CreateJobPosting(ctx context.Context, jobPosting *entity.JobPosting) (*entity.JobPosting, error) {
-	if err := r.db.WithContext(ctx).Create(jobPosting).Error; err != nil {
+	if err := r.ensureCompanyOwned(ctx, jobPosting.CompanyID); err != nil {
 		return nil, err
 	}
+	jobPosting.Version = 1
+	err := transaction.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(jobPosting).Error; err != nil {
+			return err
+		}
+		return touchCompanies(tx, jobPosting.CompanyID)
+	})
+	if apperror.IsDuplicateEntry(err) {
+		return nil, repository.ErrDuplicateJobPostingTitle
+	}
+	if err != nil {
+		return nil, apperror.FromDB(err, "")
+	}
 	return jobPosting, nil
 }
 
 func (r *jobPostingRepository) UpdateJobPosting(ctx context.Context, jobPosting *entity.JobPosting) (*entity.JobPosting, error) {
-	// 既存の求人情報を取得
-	var existingJobPosting entity.JobPosting
-	if err := r.db.First(&existingJobPosting, jobPosting.ID).Error; err != nil {
+	scope, err := r.ownedScope(ctx)
+	if err != nil {
 		return nil, err
 	}
 
 	// 更新対象のフィールドを設定
 	updates := map[string]interface{}{
-		"company_id":  jobPosting.CompanyID,
 		"title":       jobPosting.Title,
 		"description": jobPosting.Description,
+		"version":     gorm.Expr("version + 1"),
 		"updated_at":  time.Now(),
 	}
 
 	// トランザクションを開始
-	err := r.db.Transaction(func(tx *gorm.DB) error {
+	err = transaction.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		// 行ロックを取得してバージョンを確認する（他のワークスペースの求人は存在しないものとして扱う）
+		current, err := lockVersion(tx, scope, jobPosting.ID, jobPosting.Version)
+		if err != nil {
+			return err
+		}
+
 		// 求人情報を更新
 		if err := tx.Model(&entity.JobPosting{}).Where("id = ?", jobPosting.ID).Updates(updates).Error; err != nil {
 			return err
 		}
 
-		// 既存のカスタムフィールドを削除
-		if err := tx.Where("job_id = ?", jobPosting.ID).Delete(&entity.JobCustomField{}).Error; err != nil {
+		// カスタムフィールドを ID ごとに追加・更新・削除する
+		if err := syncCustomFields(tx, jobPosting.ID, jobPosting.CustomFields); err != nil {
 			return err
 		}
+		return touchCompanies(tx, current.CompanyID)
+	})
+
+	if apperror.IsDuplicateEntry(err) {
+		return nil, repository.ErrDuplicateJobPostingTitle
+	}
+	if err != nil {
+		return nil, apperror.FromDB(err, "job posting not found")
+	}
+
+	// 更新後の求人情報を取得して返す
+	var updatedJobPosting entity.JobPosting
+	if err := transaction.Conn(ctx, r.db).Scopes(scope).Preload("CustomFields").First(&updatedJobPosting, jobPosting.ID).Error; err != nil {
+		return nil, apperror.FromDB(err, "job posting not found")
+	}
+
+	return &updatedJobPosting, nil
+}
 
-		// 新しいカスタムフィールドを作成
-		if len(jobPosting.CustomFields) > 0 {
-			for i := range jobPosting.CustomFields {
-				jobPosting.CustomFields[i].JobID = jobPosting.ID
-				jobPosting.CustomFields[i].CreatedAt = time.Now()
-				jobPosting.CustomFields[i].UpdatedAt = time.Now()
-			}
-			if err := tx.Create(&jobPosting.CustomFields).Error; err != nil {
-				return err
-			}
+// lockVersion は求人情報の行ロックを取得し、version が 0 でなければ現在のバージョンと一致することを確認します。
+// 一致しない場合は ErrVersionMismatch を返します。
+func lockVersion(tx *gorm.DB, scope func(*gorm.DB) *gorm.DB, id, version int) (*entity.JobPosting, error) {
+	var current entity.JobPosting
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Scopes(scope).
+		Select("id", "company_id", "version").
+		First(&current, id).Error; err != nil {
+		return nil, err
+	}
+	if version != 0 && version != current.Version {
+		return nil, repository.ErrVersionMismatch
+	}
+	return &current, nil
+}
+
+// touchCompanies は企業のバージョンを 1 増やします。
+// 企業のレスポンスは求人情報を含むため、求人情報の変更時に企業の ETag も変わるようにします。
+func touchCompanies(tx *gorm.DB, companyIDs ...int) error {
+	return tx.Model(&entity.Company{}).
+		Where("id IN ?", companyIDs).
+		UpdateColumn("version", gorm.Expr("version + 1")).Error
+}
+
+// syncCustomFields は求人情報のカスタムフィールドを fields に一致させます。
+// ID が 0 の項目は追加、ID を持つ項目は内容が変わった場合のみ更新し、fields に含まれない既存の項目は削除します。
+// 求人情報に属さない ID が含まれる場合は ErrCustomFieldNotFound を返します。
+func syncCustomFields(tx *gorm.DB, jobID int, fields []entity.JobCustomField) error {
+	var existing []entity.JobCustomField
+	if err := tx.Where("job_id = ?", jobID).Find(&existing).Error; err != nil {
+		return err
+	}
+	current := make(map[int]entity.JobCustomField, len(existing))
+	for _, field := range existing {
+		current[field.ID] = field
+	}
+
+	now := time.Now()
+	kept := make(map[int]bool, len(fields))
+	var added []entity.JobCustomField
+	for _, field := range fields {
+		if field.ID == 0 {
+			field.JobID = jobID
+			field.CreatedAt = now
+			field.UpdatedAt = now
+			added = append(added, field)
+			continue
+		}
+		before, ok := current[field.ID]
+		if !ok || kept[field.ID] {
+			return repository.ErrCustomFieldNotFound
+		}
+		kept[field.ID] = true
+		if before.FieldName == field.FieldName && before.Content == field.Content {
+			continue
 		}
+		if err := tx.Model(&entity.JobCustomField{}).Where("id = ?", field.ID).Updates(map[string]interface{}{
+			"field_name": field.FieldName,
+			"content":    field.Content,
+			"updated_at": now,
+		}).Error; err != nil {
+			return err
+		}
+	}
+
+	var removed []int
+	for _, field := range existing {
+		if !kept[field.ID] {
+			removed = append(removed, field.ID)
+		}
+	}
+	if len(removed) > 0 {
+		if err := tx.Where("job_id = ? AND id IN ?", jobID, removed).Delete(&entity.JobCustomField{}).Error; err != nil {
+			return err
+		}
+	}
+	if len(added) > 0 {
+		return tx.Create(&added).Error
+	}
+	return nil
+}
+
+func (r *jobPostingRepository) MoveJobPosting(ctx context.Context, move *entity.JobPostingMove) (*entity.JobPosting, error) {
+	scope, err := r.ownedScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// 移動先の企業も呼び出し元のワークスペースに属する必要がある
+	if err := r.ensureCompanyOwned(ctx, move.ToCompanyID); err != nil {
+		return nil, err
+	}
 
-		return nil
+	err = transaction.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		// 行ロックを取得して移動元の企業を確定する（他のワークスペースの求人は存在しないものとして扱う）
+		var current entity.JobPosting
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Scopes(scope).
+			Select("id", "company_id").
+			First(&current, move.JobPostingID).Error; err != nil {
+			return err
+		}
+		move.FromCompanyID = current.CompanyID
+		move.CreatedAt = time.Now()
+
+		if err := tx.Model(&entity.JobPosting{}).Where("id = ?", move.JobPostingID).Updates(map[string]interface{}{
+			"company_id": move.ToCompanyID,
+			"version":    gorm.Expr("version + 1"),
+			"updated_at": time.Now(),
+		}).Error; err != nil {
+			return err
+		}
+		if err := touchCompanies(tx, move.FromCompanyID, move.ToCompanyID); err != nil {
+			return err
+		}
+		return tx.Create(move).Error
 	})
+	if apperror.IsDuplicateEntry(err) {
+		// 移動先の企業に同じタイトルの求人が存在する
+		return nil, repository.ErrDuplicateJobPostingTitle
+	}
+	if err != nil {
+		return nil, apperror.FromDB(err, "job posting not found")
+	}
+
+	var moved entity.JobPosting
+	if err := transaction.Conn(ctx, r.db).Preload("CustomFields").First(&moved, move.JobPostingID).Error; err != nil {
+		return nil, apperror.FromDB(err, "job posting not found")
+	}
+	return &moved, nil
+}
 
+func (r *jobPostingRepository) ListJobPostingMoves(ctx context.Context, jobPostingID int) ([]entity.JobPostingMove, error) {
+	db, err := r.owned(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	// 更新後の求人情報を取得して返す
-	var updatedJobPosting entity.JobPosting
-	if err := r.db.Preload("CustomFields").First(&updatedJobPosting, jobPosting.ID).Error; err != nil {
+	// 求人が呼び出し元のワークスペースに属することを確認する
+	if err := db.Select("id").First(&entity.JobPosting{}, jobPostingID).Error; err != nil {
+		return nil, apperror.FromDB(err, "job posting not found")
+	}
+
+	var moves []entity.JobPostingMove
+	if err := transaction.Conn(ctx, r.db).
+		Where("job_posting_id = ?", jobPostingID).
+		Order("id").
+		Find(&moves).Error; err != nil {
 		return nil, err
 	}
+	return moves, nil
+}
 
-	return &updatedJobPosting, nil
+func (r *jobPostingRepository) DeleteJobPosting(ctx context.Context, id, version int) error {
+	scope, err := r.ownedScope(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = transaction.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		current, err := lockVersion(tx, scope, id, version)
+		if err != nil {
+			return err
+		}
+		// ゴミ箱に移動する（カスタムフィールドは完全に削除するまで残す）
+		if err := tx.Delete(&entity.JobPosting{}, id).Error; err != nil {
+			return err
+		}
+		return touchCompanies(tx, current.CompanyID)
+	})
+	return apperror.FromDB(err, "job posting not found")
+}
+
+func (r *jobPostingRepository) ListDeletedJobPostings(ctx context.Context) ([]entity.JobPosting, error) {
+	db, err := r.owned(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// 削除されていない企業の求人のみ（削除済みの企業の求人は企業と共に元に戻す）
+	var jobPostings []entity.JobPosting
+	if err := db.Unscoped().
+		Preload("CustomFields").
+		Where("job_postings.deleted_at IS NOT NULL").
+		Order("job_postings.deleted_at DESC, job_postings.id DESC").
+		Find(&jobPostings).Error; err != nil {
+		return nil, err
+	}
+	return jobPostings, nil
+}
+
+func (r *jobPostingRepository) RestoreJobPosting(ctx context.Context, id int) (*entity.JobPosting, error) {
+	scope, err := r.ownedScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = transaction.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var current entity.JobPosting
+		if err := tx.Unscoped().
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Scopes(scope).
+			Select("id", "company_id").
+			Where("job_postings.deleted_at IS NOT NULL").
+			First(&current, id).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Model(&entity.JobPosting{}).Where("id = ?", id).Updates(map[string]interface{}{
+			"deleted_at": nil,
+			"version":    gorm.Expr("version + 1"),
+		}).Error; err != nil {
+			return err
+		}
+		return touchCompanies(tx, current.CompanyID)
+	})
+	if apperror.IsDuplicateEntry(err) {
+		// ゴミ箱に移動した後に同じ企業に同じタイトルの求人が作成された
+		return nil, repository.ErrDuplicateJobPostingTitle
+	}
+	if err != nil {
+		return nil, apperror.FromDB(err, "deleted job posting not found")
+	}
+	return r.GetJobPosting(ctx, id)
 }
 
-func (r *jobPostingRepository) DeleteJobPosting(ctx context.Context, id int) error {
-	// カスタムフィールドは外部キー制約で自動的に削除される
-	return r.db.WithContext(ctx).Delete(&entity.JobPosting{}, id).Error
+func (r *jobPostingRepository) PurgeDeletedJobPostings(ctx context.Context, before time.Time) (int64, error) {
+	// カスタムフィールド・移動履歴は外部キー制約で削除され、面接セッションの参照は NULL になる
+	result := transaction.Conn(ctx, r.db).Unscoped().Where("deleted_at < ?", before).Delete(&entity.JobPosting{})
+	return result.RowsAffected, result.Error
 }
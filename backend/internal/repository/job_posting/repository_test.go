@@ -0,0 +1,126 @@
+package job_posting_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gorm.io/gorm"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/repository/company"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/repository/job_posting"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/repository/repositorytest"
+)
+
+const (
+	userA        = 1
+	userB        = 2
+	organization = 10
+)
+
+// otherWorkspaces は userA の個人のワークスペースの求人情報を参照できないワークスペースです
+var otherWorkspaces = map[string]context.Context{
+	"other user":             repositorytest.UserContext(userB),
+	"same user in org":       repositorytest.MemberContext(userA, organization, entity.MembershipRoleOwner),
+	"other user in same org": repositorytest.MemberContext(userB, organization, entity.MembershipRoleAdmin),
+}
+
+// fixture は userA の個人のワークスペースに企業と求人情報を1件ずつ作成します
+type fixture struct {
+	db         *gorm.DB
+	ctx        context.Context
+	company    *entity.Company
+	jobPosting *entity.JobPosting
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+	db := repositorytest.NewDB(t)
+	ctx := repositorytest.UserContext(userA)
+
+	c := &entity.Company{Name: "A社"}
+	if err := company.NewRepository(db).CreateCompany(ctx, c); err != nil {
+		t.Fatalf("CreateCompany: %v", err)
+	}
+	description := "Go でのバックエンド開発"
+	jobPosting, err := job_posting.NewRepository(db).CreateJobPosting(ctx, &entity.JobPosting{
+		CompanyID:    c.ID,
+		Title:        "バックエンドエンジニア",
+		Description:  &description,
+		CustomFields: []entity.JobCustomField{{FieldName: "勤務地", Content: "東京"}},
+	})
+	if err != nil {
+		t.Fatalf("CreateJobPosting: %v", err)
+	}
+	return &fixture{db: db, ctx: ctx, company: c, jobPosting: jobPosting}
+}
+
+func TestJobPostingIsNotFoundFromOtherWorkspaces(t *testing.T) {
+	for name, ctx := range otherWorkspaces {
+		t.Run(name, func(t *testing.T) {
+			f := newFixture(t)
+			repo := job_posting.NewRepository(f.db)
+
+			if _, err := repo.GetJobPosting(ctx, f.jobPosting.ID); !errors.Is(err, gorm.ErrRecordNotFound) {
+				t.Errorf("GetJobPosting error = %v, want ErrRecordNotFound", err)
+			}
+			if _, err := repo.ListJobPostingsByCompany(ctx, f.company.ID, 1, 10); !errors.Is(err, gorm.ErrRecordNotFound) {
+				t.Errorf("ListJobPostingsByCompany error = %v, want ErrRecordNotFound", err)
+			}
+
+			update := &entity.JobPosting{ID: f.jobPosting.ID, Title: "乗っ取り", Version: f.jobPosting.Version}
+			if _, err := repo.UpdateJobPosting(ctx, update); !errors.Is(err, gorm.ErrRecordNotFound) {
+				t.Errorf("UpdateJobPosting error = %v, want ErrRecordNotFound", err)
+			}
+			if err := repo.DeleteJobPosting(ctx, f.jobPosting.ID, 0); !errors.Is(err, gorm.ErrRecordNotFound) {
+				t.Errorf("DeleteJobPosting error = %v, want ErrRecordNotFound", err)
+			}
+
+			// 所有者からは変更されずに参照できる
+			got, err := repo.GetJobPosting(f.ctx, f.jobPosting.ID)
+			if err != nil {
+				t.Fatalf("GetJobPosting by owner: %v", err)
+			}
+			if got.Title != "バックエンドエンジニア" || got.Version != f.jobPosting.Version || len(got.CustomFields) != 1 {
+				t.Errorf("job posting was modified: title=%q version=%d custom_fields=%d", got.Title, got.Version, len(got.CustomFields))
+			}
+		})
+	}
+}
+
+func TestCreateJobPostingInOtherWorkspaceCompany(t *testing.T) {
+	for name, ctx := range otherWorkspaces {
+		t.Run(name, func(t *testing.T) {
+			f := newFixture(t)
+			repo := job_posting.NewRepository(f.db)
+
+			_, err := repo.CreateJobPosting(ctx, &entity.JobPosting{CompanyID: f.company.ID, Title: "不正な求人"})
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				t.Errorf("CreateJobPosting error = %v, want ErrRecordNotFound", err)
+			}
+
+			// 他のワークスペースからの求人情報は作成されない
+			list, err := repo.ListJobPostingsByCompany(f.ctx, f.company.ID, 1, 10)
+			if err != nil {
+				t.Fatalf("ListJobPostingsByCompany by owner: %v", err)
+			}
+			if list.Total != 1 {
+				t.Errorf("Total = %d, want 1", list.Total)
+			}
+		})
+	}
+}
+
+func TestListJobPostingsByCompanyOwner(t *testing.T) {
+	f := newFixture(t)
+	repo := job_posting.NewRepository(f.db)
+
+	list, err := repo.ListJobPostingsByCompany(f.ctx, f.company.ID, 1, 10)
+	if err != nil {
+		t.Fatalf("ListJobPostingsByCompany: %v", err)
+	}
+	if list.Total != 1 || len(list.JobPostings) != 1 || list.JobPostings[0].ID != f.jobPosting.ID {
+		t.Errorf("ListJobPostingsByCompany = %+v, want the owner's job posting", list)
+	}
+}
@@ -0,0 +1,138 @@
+package organization
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/apperror"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/repository"
+)
+
+type organizationRepository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) repository.OrganizationRepository {
+	return &organizationRepository{db: db}
+}
+
+func (r *organizationRepository) CreateOrganization(ctx context.Context, organization *entity.Organization, ownerUserID int) (*entity.Membership, error) {
+	membership := &entity.Membership{UserID: ownerUserID, Role: entity.MembershipRoleOwner}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(organization).Error; err != nil {
+			return err
+		}
+		membership.OrganizationID = organization.ID
+		return tx.Create(membership).Error
+	})
+	if err != nil {
+		return nil, apperror.FromDB(err, "")
+	}
+	membership.Organization = organization
+	return membership, nil
+}
+
+func (r *organizationRepository) ListMembershipsByUser(ctx context.Context, userID int) ([]entity.Membership, error) {
+	var memberships []entity.Membership
+	if err := r.db.WithContext(ctx).
+		Preload("Organization").
+		Where("user_id = ?", userID).
+		Order("organization_id").
+		Find(&memberships).Error; err != nil {
+		return nil, err
+	}
+	return memberships, nil
+}
+
+func (r *organizationRepository) GetMembership(ctx context.Context, organizationID, userID int) (*entity.Membership, error) {
+	var membership entity.Membership
+	if err := r.db.WithContext(ctx).
+		Where("organization_id = ? AND user_id = ?", organizationID, userID).
+		First(&membership).Error; err != nil {
+		return nil, apperror.FromDB(err, "organization not found")
+	}
+	return &membership, nil
+}
+
+func (r *organizationRepository) ListMembers(ctx context.Context, organizationID int) ([]entity.Membership, error) {
+	var memberships []entity.Membership
+	if err := r.db.WithContext(ctx).
+		Preload("User").
+		Where("organization_id = ?", organizationID).
+		Order("id").
+		Find(&memberships).Error; err != nil {
+		return nil, err
+	}
+	return memberships, nil
+}
+
+func (r *organizationRepository) CreateInvitation(ctx context.Context, invitation *entity.OrganizationInvitation) error {
+	return apperror.FromDB(r.db.WithContext(ctx).Create(invitation).Error, "")
+}
+
+func (r *organizationRepository) AcceptInvitation(ctx context.Context, tokenHash string, userID int, now time.Time) (*entity.Membership, error) {
+	var membership *entity.Membership
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 同じ招待が同時に使用されないよう行ロックを取得する
+		var invitation entity.OrganizationInvitation
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("token_hash = ?", tokenHash).
+			First(&invitation).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return repository.ErrInvitationUnavailable
+		}
+		if err != nil {
+			return err
+		}
+		if invitation.AcceptedAt != nil || !now.Before(invitation.ExpiresAt) {
+			return repository.ErrInvitationUnavailable
+		}
+
+		// 宛先を指定した招待は、確認済みのメールアドレスが一致するユーザーのみ受諾できる（大文字・小文字は区別しない）
+		if invitation.Email != nil {
+			var user entity.User
+			if err := tx.Select("id", "email", "email_verified").First(&user, userID).Error; err != nil {
+				return err
+			}
+			if user.Email == nil || !user.EmailVerified || !strings.EqualFold(*user.Email, *invitation.Email) {
+				return repository.ErrInvitationEmailMismatch
+			}
+		}
+
+		var count int64
+		if err := tx.Model(&entity.Membership{}).
+			Where("organization_id = ? AND user_id = ?", invitation.OrganizationID, userID).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return repository.ErrAlreadyMember
+		}
+
+		membership = &entity.Membership{
+			OrganizationID: invitation.OrganizationID,
+			UserID:         userID,
+			Role:           invitation.Role,
+		}
+		if err := tx.Create(membership).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&entity.OrganizationInvitation{}).
+			Where("id = ?", invitation.ID).
+			Updates(map[string]interface{}{
+				"accepted_at":         now,
+				"accepted_by_user_id": userID,
+			}).Error
+	})
+	if err != nil {
+		return nil, apperror.FromDB(err, "")
+	}
+	return membership, nil
+}
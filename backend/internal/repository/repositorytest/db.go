@@ -0,0 +1,176 @@
+// Package repositorytest はリポジトリのテストで使用するインメモリのデータベースと認証コンテキストを提供します。
+// テストからのみ使用してください。
+package repositorytest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/auth"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
+)
+
+// schema は企業・求人情報・面接の記録・ジョブの表を SQLite で再現したものです（全文検索のインデックスと生成列は含みません）
+var schema = []string{
+	`CREATE TABLE companies (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		owner_user_id INTEGER NULL,
+		organization_id INTEGER NULL,
+		name VARCHAR(100) NOT NULL,
+		business_description TEXT,
+		version INTEGER NOT NULL DEFAULT 1,
+		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		deleted_at DATETIME NULL
+	)`,
+	`CREATE TABLE company_custom_fields (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		company_id INTEGER NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+		field_name VARCHAR(50) NOT NULL,
+		content TEXT NOT NULL,
+		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`,
+	`CREATE TABLE job_postings (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		company_id INTEGER NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+		title VARCHAR(100) NOT NULL,
+		description TEXT,
+		version INTEGER NOT NULL DEFAULT 1,
+		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		deleted_at DATETIME NULL
+	)`,
+	`CREATE TABLE job_custom_fields (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		job_id INTEGER NOT NULL REFERENCES job_postings(id) ON DELETE CASCADE,
+		field_name VARCHAR(50) NOT NULL,
+		content TEXT NOT NULL,
+		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`,
+	`CREATE TABLE interview_sessions (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		owner_user_id INTEGER NULL,
+		organization_id INTEGER NULL,
+		company_id INTEGER NULL REFERENCES companies(id) ON DELETE SET NULL,
+		job_posting_id INTEGER NULL REFERENCES job_postings(id) ON DELETE SET NULL,
+		interview_phase VARCHAR(100),
+		interviewer_role VARCHAR(100),
+		question_count INTEGER NOT NULL,
+		include_self_introduction BOOLEAN NOT NULL,
+		include_ice_break BOOLEAN NOT NULL,
+		status VARCHAR(20) NOT NULL DEFAULT 'CREATED',
+		started_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		ended_at DATETIME NULL
+	)`,
+	`CREATE TABLE interview_questions (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		session_id INTEGER NOT NULL REFERENCES interview_sessions(id) ON DELETE CASCADE,
+		content TEXT NOT NULL,
+		sequence INTEGER NOT NULL,
+		prompt_version VARCHAR(50) NULL,
+		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		UNIQUE (session_id, sequence)
+	)`,
+	`CREATE TABLE interview_answers (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		question_id INTEGER NOT NULL UNIQUE REFERENCES interview_questions(id) ON DELETE CASCADE,
+		content TEXT NOT NULL,
+		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`,
+	`CREATE TABLE interview_evaluations (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		session_id INTEGER NOT NULL UNIQUE REFERENCES interview_sessions(id) ON DELETE CASCADE,
+		total_rank VARCHAR(1) NOT NULL,
+		total_score INTEGER NOT NULL,
+		overall_comment TEXT NOT NULL,
+		logical_score INTEGER NOT NULL,
+		communication_score INTEGER NOT NULL,
+		technical_score INTEGER NOT NULL,
+		problem_solving_score INTEGER NOT NULL,
+		motivation_score INTEGER NOT NULL,
+		culture_fit_score INTEGER NOT NULL,
+		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`,
+	`CREATE TABLE answer_evaluations (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		session_id INTEGER NOT NULL REFERENCES interview_sessions(id) ON DELETE CASCADE,
+		question_id INTEGER NOT NULL UNIQUE REFERENCES interview_questions(id) ON DELETE CASCADE,
+		logical_score INTEGER NOT NULL,
+		communication_score INTEGER NOT NULL,
+		technical_score INTEGER NOT NULL,
+		problem_solving_score INTEGER NOT NULL,
+		motivation_score INTEGER NOT NULL,
+		culture_fit_score INTEGER NOT NULL,
+		question_comment TEXT NOT NULL,
+		strengths JSON NOT NULL,
+		improvements JSON NOT NULL,
+		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`,
+	`CREATE TABLE jobs (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		owner_user_id INTEGER NULL,
+		organization_id INTEGER NULL,
+		type VARCHAR(100) NOT NULL,
+		payload JSON NOT NULL,
+		status VARCHAR(20) NOT NULL DEFAULT 'pending',
+		attempts INTEGER NOT NULL DEFAULT 0,
+		max_attempts INTEGER NOT NULL,
+		timeout_seconds INTEGER NOT NULL,
+		locked_by VARCHAR(128) NULL,
+		lease_expires_at DATETIME NULL,
+		last_error TEXT NULL,
+		result JSON NULL,
+		run_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		started_at DATETIME NULL,
+		finished_at DATETIME NULL,
+		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`,
+}
+
+// NewDB はテストごとに独立したインメモリのデータベースを作成し、企業・求人情報・面接の記録・ジョブの表を作成して返します
+func NewDB(t testing.TB) *gorm.DB {
+	t.Helper()
+
+	// 同じデータベースを全ての接続で共有するため、テストごとに名前を付けた共有キャッシュを使用する
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared&_pragma=foreign_keys(1)", t.Name())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("get database: %v", err)
+	}
+	// トランザクション中の書き込みと他の接続の読み込みが競合しないよう、接続を1つに制限する
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	for _, statement := range schema {
+		if err := db.Exec(statement).Error; err != nil {
+			t.Fatalf("create schema: %v", err)
+		}
+	}
+	return db
+}
+
+// UserContext は userID のユーザーとして個人のワークスペースで認証されたコンテキストを返します
+func UserContext(userID int) context.Context {
+	return auth.WithUser(context.Background(), &entity.User{ID: userID})
+}
+
+// MemberContext は userID のユーザーとして organizationID の組織のワークスペースで認証されたコンテキストを返します
+func MemberContext(userID, organizationID int, role entity.MembershipRole) context.Context {
+	return auth.WithMembership(UserContext(userID), &entity.Membership{
+		OrganizationID: organizationID,
+		UserID:         userID,
+		Role:           role,
+	})
+}
@@ -0,0 +1,69 @@
+package user
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/apperror"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/repository"
+)
+
+type userRepository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) repository.UserRepository {
+	return &userRepository{db: db}
+}
+
+func (r *userRepository) GetUser(ctx context.Context, id int) (*entity.User, error) {
+	var user entity.User
+	if err := r.db.WithContext(ctx).First(&user, id).Error; err != nil {
+		return nil, apperror.FromDB(err, "user not found")
+	}
+	return &user, nil
+}
+
+func (r *userRepository) UpsertUserBySubject(ctx context.Context, user *entity.User) error {
+	db := r.db.WithContext(ctx)
+
+	var existing entity.User
+	err := db.Where("auth_subject = ?", user.AuthSubject).First(&existing).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = db.Create(user).Error
+		if !apperror.IsDuplicateEntry(err) {
+			return err
+		}
+		// 同時に作成された場合は作成済みのユーザーを使用する
+		err = db.Where("auth_subject = ?", user.AuthSubject).First(&existing).Error
+	}
+	if err != nil {
+		return err
+	}
+
+	if !equalStringPtr(existing.Email, user.Email) || existing.EmailVerified != user.EmailVerified || !equalStringPtr(existing.Name, user.Name) {
+		if err := db.Model(&existing).Updates(map[string]interface{}{
+			"email":          user.Email,
+			"email_verified": user.EmailVerified,
+			"name":           user.Name,
+		}).Error; err != nil {
+			return err
+		}
+		existing.Email = user.Email
+		existing.EmailVerified = user.EmailVerified
+		existing.Name = user.Name
+	}
+
+	*user = existing
+	return nil
+}
+
+func equalStringPtr(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
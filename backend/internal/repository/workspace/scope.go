@@ -0,0 +1,105 @@
+package workspace
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/auth"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
+)
+
+// Companies は呼び出し元のワークスペースに属する企業に絞り込むスコープを返します。
+// 組織のワークスペースでは組織の企業、個人のワークスペースではユーザーが所有し組織に属さない企業が対象です。
+func Companies(ctx context.Context) (func(*gorm.DB) *gorm.DB, error) {
+	userID, err := auth.UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if membership, ok := auth.MembershipFromContext(ctx); ok {
+		return func(db *gorm.DB) *gorm.DB {
+			return db.Where("companies.organization_id = ?", membership.OrganizationID)
+		}, nil
+	}
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("companies.owner_user_id = ? AND companies.organization_id IS NULL", userID)
+	}, nil
+}
+
+// AssignCompany は作成する企業を呼び出し元のワークスペースに所属させます。
+// owner_user_id には作成したユーザーを設定します。
+func AssignCompany(ctx context.Context, company *entity.Company) error {
+	userID, err := auth.UserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	company.OwnerUserID = userID
+	company.OrganizationID = nil
+	if membership, ok := auth.MembershipFromContext(ctx); ok {
+		organizationID := membership.OrganizationID
+		company.OrganizationID = &organizationID
+	}
+	return nil
+}
+
+// Sessions は呼び出し元が作成した面接セッションに絞り込むスコープを返します。
+// 面接の回答は個人のものであるため、組織のワークスペースでも他のメンバーのセッションは対象外です。
+func Sessions(ctx context.Context) (func(*gorm.DB) *gorm.DB, error) {
+	return ownedBy(ctx, "interview_sessions")
+}
+
+// AssignSession は作成する面接セッションの所有者を呼び出し元のユーザーとワークスペースに設定します。
+func AssignSession(ctx context.Context, session *entity.InterviewSession) error {
+	userID, organizationID, err := owner(ctx)
+	if err != nil {
+		return err
+	}
+	session.OwnerUserID = userID
+	session.OrganizationID = organizationID
+	return nil
+}
+
+// Jobs は呼び出し元が登録した非同期ジョブに絞り込むスコープを返します。
+func Jobs(ctx context.Context) (func(*gorm.DB) *gorm.DB, error) {
+	return ownedBy(ctx, "jobs")
+}
+
+// AssignJob は登録するジョブの所有者を呼び出し元のユーザーとワークスペースに設定します。
+func AssignJob(ctx context.Context, job *entity.Job) error {
+	userID, organizationID, err := owner(ctx)
+	if err != nil {
+		return err
+	}
+	job.OwnerUserID = userID
+	job.OrganizationID = organizationID
+	return nil
+}
+
+// ownedBy は table の行のうち、呼び出し元のユーザーが現在のワークスペースで作成したものに絞り込むスコープを返します。
+func ownedBy(ctx context.Context, table string) (func(*gorm.DB) *gorm.DB, error) {
+	userID, organizationID, err := owner(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if organizationID != nil {
+		return func(db *gorm.DB) *gorm.DB {
+			return db.Where(table+".owner_user_id = ? AND "+table+".organization_id = ?", userID, *organizationID)
+		}, nil
+	}
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(table+".owner_user_id = ? AND "+table+".organization_id IS NULL", userID)
+	}, nil
+}
+
+// owner は呼び出し元のユーザー ID とワークスペースの組織 ID（個人のワークスペースの場合は nil）を返します。
+func owner(ctx context.Context) (int, *int, error) {
+	userID, err := auth.UserIDFromContext(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+	if membership, ok := auth.MembershipFromContext(ctx); ok {
+		organizationID := membership.OrganizationID
+		return userID, &organizationID, nil
+	}
+	return userID, nil, nil
+}
@@ -3,15 +3,25 @@ package routes
 import (
 	"github.com/gin-gonic/gin"
 
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
 	"github.com/takanoakira/ai-interview-practice/backend/internal/handler/company"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/middleware"
 )
 
-func SetupCompanyRoutes(r *gin.Engine, h company.Handler) {
+// SetupCompanyRoutes は企業情報のルートを登録します。ifMatch は更新・削除に設定する If-Match の確認です（middleware.RequireIfMatch）。
+func SetupCompanyRoutes(r *gin.Engine, h company.Handler, ifMatch gin.HandlerFunc) {
+	// 組織ワークスペースでは企業・求人情報の登録・更新・削除を coach 以上に限定する（candidate は閲覧のみ）
+	curate := middleware.RequireRole(entity.MembershipRoleOwner, entity.MembershipRoleAdmin, entity.MembershipRoleCoach)
+
 	companies := r.Group("/api/v1/companies")
 	{
 		companies.GET("", h.GetCompanies)
-		companies.POST("", h.CreateCompany)
-		companies.PUT("/:id", h.UpdateCompany)
-		companies.DELETE("/:id", h.DeleteCompany)
+		companies.GET("/:id", h.GetCompany)
+		companies.POST("", curate, h.CreateCompany)
+		companies.PUT("/:id", curate, ifMatch, h.UpdateCompany)
+		companies.PATCH("/:id", curate, ifMatch, h.PatchCompany)
+		companies.DELETE("/:id", curate, ifMatch, h.DeleteCompany)
+		// 削除はゴミ箱への移動（保持期間を過ぎると完全に削除される）
+		companies.POST("/:id/restore", curate, h.RestoreCompany)
 	}
 }
@@ -0,0 +1,12 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/handler/export"
+)
+
+// SetupExportRoutes はエクスポートのルートを登録します。閲覧できるデータのみを含むため、全てのロールで利用できます。
+func SetupExportRoutes(r *gin.Engine, h export.Handler) {
+	r.GET("/api/v1/exports", h.Export)
+}
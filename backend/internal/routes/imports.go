@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/handler/imports"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/middleware"
+)
+
+// SetupImportRoutes は企業・求人情報の一括インポートのルートを登録します
+func SetupImportRoutes(r *gin.Engine, h imports.Handler) {
+	// 企業・求人情報の登録と同じく coach 以上に限定する
+	curate := middleware.RequireRole(entity.MembershipRoleOwner, entity.MembershipRoleAdmin, entity.MembershipRoleCoach)
+
+	r.POST("/api/v1/imports", curate, h.Import)
+	// 求人ページ・JSON-LD の schema.org の JobPosting を取り込む
+	r.POST("/api/v1/imports/job-postings", curate, h.ImportJobPostings)
+}
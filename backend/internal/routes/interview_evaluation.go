@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/handler/interview_evaluation"
+)
+
+func SetupInterviewEvaluationRoutes(r *gin.Engine, h interview_evaluation.Handler) {
+	sessions := r.Group("/api/v1/interview-sessions")
+	{
+		sessions.POST("/:id/evaluate", h.Evaluate)
+		sessions.GET("/:id/evaluation", h.GetEvaluation)
+	}
+}
@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/handler/interview_session"
+)
+
+func SetupInterviewSessionRoutes(r *gin.Engine, h interview_session.Handler) {
+	sessions := r.Group("/api/v1/interview-sessions")
+	{
+		sessions.POST("", h.CreateSession)
+		sessions.GET("/:id/greeting", h.GetGreeting)
+		sessions.POST("/:id/self-introduction", h.SubmitSelfIntroduction)
+		sessions.POST("/:id/ice-break", h.SubmitIceBreak)
+		sessions.POST("/:id/question", h.SubmitQuestion)
+		sessions.POST("/:id/question/stream", h.StreamQuestion)
+		sessions.POST("/:id/pause", h.PauseSession)
+		sessions.POST("/:id/resume", h.ResumeSession)
+		sessions.POST("/:id/terminate", h.TerminateSession)
+	}
+}
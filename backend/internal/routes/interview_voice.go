@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/handler/interview_voice"
+)
+
+func SetupInterviewVoiceRoutes(r *gin.Engine, h interview_voice.Handler) {
+	sessions := r.Group("/api/v1/interview-sessions")
+	{
+		sessions.GET("/:id/ws", h.Connect)
+	}
+}
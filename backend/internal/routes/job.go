@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/handler/job"
+)
+
+func SetupJobRoutes(r *gin.Engine, h job.Handler) {
+	jobs := r.Group("/api/v1/jobs")
+	{
+		jobs.GET("/:id", h.GetJob)
+	}
+}
@@ -3,14 +3,32 @@ package routes
 import (
 	"github.com/gin-gonic/gin"
 
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
 	"github.com/takanoakira/ai-interview-practice/backend/internal/handler/job_posting"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/middleware"
 )
 
-func SetupJobPostingRoutes(r *gin.Engine, h job_posting.Handler) {
+// SetupJobPostingRoutes は求人情報のルートを登録します。ifMatch は更新・削除に設定する If-Match の確認です（middleware.RequireIfMatch）。
+func SetupJobPostingRoutes(r *gin.Engine, h job_posting.Handler, ifMatch gin.HandlerFunc) {
+	// 組織ワークスペースでは企業・求人情報の登録・更新・削除を coach 以上に限定する（candidate は閲覧のみ）
+	curate := middleware.RequireRole(entity.MembershipRoleOwner, entity.MembershipRoleAdmin, entity.MembershipRoleCoach)
+
 	jobPostings := r.Group("/api/v1/job-postings")
 	{
-		jobPostings.POST("", h.CreateJobPosting)
-		jobPostings.PUT("/:id", h.UpdateJobPosting)
-		jobPostings.DELETE("/:id", h.DeleteJobPosting)
+		jobPostings.GET("/:id", h.GetJobPosting)
+		jobPostings.POST("", curate, h.CreateJobPosting)
+		// 求人票のテキスト・HTML から作成リクエストの下書きを作成する（保存はしない）
+		jobPostings.POST("/parse", curate, h.ParseJobPosting)
+		jobPostings.PUT("/:id", curate, ifMatch, h.UpdateJobPosting)
+		jobPostings.PATCH("/:id", curate, ifMatch, h.PatchJobPosting)
+		jobPostings.DELETE("/:id", curate, ifMatch, h.DeleteJobPosting)
+		// 削除はゴミ箱への移動（保持期間を過ぎると完全に削除される）
+		jobPostings.POST("/:id/restore", curate, h.RestoreJobPosting)
+		// 企業間の移動は更新と分けて履歴を記録する
+		jobPostings.POST("/:id/move", curate, h.MoveJobPosting)
+		jobPostings.GET("/:id/moves", h.ListJobPostingMoves)
 	}
+
+	// 企業に紐づく求人一覧
+	r.GET("/api/v1/companies/:id/job-postings", h.ListJobPostingsByCompany)
 }
@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/handler/organization"
+)
+
+func SetupOrganizationRoutes(r *gin.Engine, h organization.Handler) {
+	organizations := r.Group("/api/v1/organizations")
+	{
+		organizations.POST("", h.CreateOrganization)
+		organizations.GET("", h.ListOrganizations)
+		organizations.GET("/:id/members", h.ListMembers)
+		organizations.POST("/:id/invitations", h.CreateInvitation)
+	}
+
+	r.POST("/api/v1/invitations/:token/accept", h.AcceptInvitation)
+}
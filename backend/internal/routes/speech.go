@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/handler/speech"
+)
+
+func SetupSpeechRoutes(r *gin.Engine, h speech.Handler) {
+	api := r.Group("/api/v1")
+	{
+		api.POST("/speech-to-text", h.SpeechToText)
+		api.POST("/text-to-speech", h.TextToSpeech)
+	}
+}
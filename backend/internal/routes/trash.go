@@ -0,0 +1,12 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/handler/trash"
+)
+
+// SetupTrashRoutes はゴミ箱のルートを登録します。元に戻す操作は企業・求人情報のルート（/:id/restore）にあります。
+func SetupTrashRoutes(r *gin.Engine, h trash.Handler) {
+	r.GET("/api/v1/trash", h.ListTrash)
+}
@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/handler/user"
+)
+
+func SetupUserRoutes(r *gin.Engine, h user.Handler) {
+	users := r.Group("/api/v1/users")
+	{
+		users.GET("/me", h.GetCurrentUser)
+	}
+}
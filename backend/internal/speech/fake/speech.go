@@ -0,0 +1,90 @@
+package fake
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/speech"
+)
+
+// defaultTranscript はファイル名が指定されていない場合の認識結果です
+const defaultTranscript = "よろしくお願いいたします。"
+
+type speechToText struct{}
+
+// NewSpeechToText はファイル名を認識結果として返す決定的な SpeechToText を生成します。
+// 例: "私の強みは粘り強さです.wav" → "私の強みは粘り強さです"（"_" は空白に置き換えます）
+func NewSpeechToText() speech.SpeechToText {
+	return &speechToText{}
+}
+
+func (s *speechToText) Transcribe(ctx context.Context, audio speech.Audio) (*speech.Transcript, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("fake: %w", err)
+	}
+
+	name := filepath.Base(audio.Filename)
+	text := strings.TrimSpace(strings.ReplaceAll(strings.TrimSuffix(name, filepath.Ext(name)), "_", " "))
+	if audio.Filename == "" || text == "" || text == "." {
+		return &speech.Transcript{Text: defaultTranscript, Confidence: 0.5, Language: audio.Language}, nil
+	}
+	return &speech.Transcript{Text: text, Confidence: 1, Language: audio.Language}, nil
+}
+
+const (
+	sampleRate     = 16000
+	toneFrequency  = 440
+	toneAmplitude  = 0.3
+	secondsPerRune = 0.05
+	minSeconds     = 0.5
+	maxSeconds     = 10
+)
+
+type textToSpeech struct{}
+
+// NewTextToSpeech はテキストの長さに応じた長さの正弦波を WAV で返す決定的な TextToSpeech を生成します
+func NewTextToSpeech() speech.TextToSpeech {
+	return &textToSpeech{}
+}
+
+func (t *textToSpeech) Synthesize(ctx context.Context, req speech.SynthesisRequest) (*speech.SynthesizedAudio, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("fake: %w", err)
+	}
+
+	seconds := math.Min(math.Max(float64(utf8.RuneCountInString(req.Text))*secondsPerRune, minSeconds), maxSeconds)
+	return &speech.SynthesizedAudio{Data: sineWAV(seconds), MimeType: "audio/wav"}, nil
+}
+
+// sineWAV は 16kHz・モノラル・16bit PCM の正弦波を WAV 形式で返します
+func sineWAV(seconds float64) []byte {
+	samples := int(seconds * sampleRate)
+	dataSize := samples * 2
+
+	var buf bytes.Buffer
+	buf.Grow(44 + dataSize)
+	buf.WriteString("RIFF")
+	binary.Write(&buf, binary.LittleEndian, uint32(36+dataSize))
+	buf.WriteString("WAVE")
+	buf.WriteString("fmt ")
+	binary.Write(&buf, binary.LittleEndian, uint32(16))           // fmt チャンクサイズ
+	binary.Write(&buf, binary.LittleEndian, uint16(1))            // PCM
+	binary.Write(&buf, binary.LittleEndian, uint16(1))            // チャンネル数
+	binary.Write(&buf, binary.LittleEndian, uint32(sampleRate))   // サンプルレート
+	binary.Write(&buf, binary.LittleEndian, uint32(sampleRate*2)) // バイトレート
+	binary.Write(&buf, binary.LittleEndian, uint16(2))            // ブロックサイズ
+	binary.Write(&buf, binary.LittleEndian, uint16(16))           // ビット深度
+	buf.WriteString("data")
+	binary.Write(&buf, binary.LittleEndian, uint32(dataSize))
+	for i := 0; i < samples; i++ {
+		v := toneAmplitude * math.Sin(2*math.Pi*toneFrequency*float64(i)/sampleRate)
+		binary.Write(&buf, binary.LittleEndian, int16(v*math.MaxInt16))
+	}
+	return buf.Bytes()
+}
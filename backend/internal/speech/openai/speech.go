@@ -0,0 +1,220 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/speech"
+)
+
+const (
+	defaultBaseURL  = "https://api.openai.com/v1"
+	defaultSTTModel = "whisper-1"
+	defaultTTSModel = "tts-1"
+	defaultTTSVoice = "alloy"
+)
+
+// Config は Whisper 互換の音声認識 API・OpenAI 互換の音声合成 API への接続設定です
+type Config struct {
+	BaseURL  string
+	APIKey   string
+	STTModel string
+	TTSModel string
+	TTSVoice string
+	Timeout  time.Duration
+}
+
+type client struct {
+	config Config
+	http   *http.Client
+}
+
+func newClient(config Config) *client {
+	if config.BaseURL == "" {
+		config.BaseURL = defaultBaseURL
+	}
+	config.BaseURL = strings.TrimRight(config.BaseURL, "/")
+	if config.STTModel == "" {
+		config.STTModel = defaultSTTModel
+	}
+	if config.TTSModel == "" {
+		config.TTSModel = defaultTTSModel
+	}
+	if config.TTSVoice == "" {
+		config.TTSVoice = defaultTTSVoice
+	}
+	return &client{config: config, http: &http.Client{Timeout: config.Timeout}}
+}
+
+type speechToText struct {
+	*client
+}
+
+// NewSpeechToText は Whisper 互換の /audio/transcriptions を利用する SpeechToText を生成します
+func NewSpeechToText(config Config) speech.SpeechToText {
+	return &speechToText{client: newClient(config)}
+}
+
+type transcriptionResponse struct {
+	Text     string `json:"text"`
+	Language string `json:"language"`
+	Segments []struct {
+		Start        float64 `json:"start"`
+		End          float64 `json:"end"`
+		AvgLogprob   float64 `json:"avg_logprob"`
+		NoSpeechProb float64 `json:"no_speech_prob"`
+	} `json:"segments"`
+}
+
+// fileExtensions は MIME タイプに対応するアップロード時のファイル名拡張子です（API は拡張子で形式を判定します）
+var fileExtensions = map[string]string{
+	"audio/wav":  "wav",
+	"audio/webm": "webm",
+	"audio/ogg":  "ogg",
+}
+
+func (s *speechToText) Transcribe(ctx context.Context, audio speech.Audio) (*speech.Transcript, error) {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	ext := fileExtensions[audio.MimeType]
+	if ext == "" {
+		ext = "wav"
+	}
+	file, err := form.CreateFormFile("file", "audio."+ext)
+	if err != nil {
+		return nil, fmt.Errorf("speech: build request: %w", err)
+	}
+	if _, err := file.Write(audio.Data); err != nil {
+		return nil, fmt.Errorf("speech: build request: %w", err)
+	}
+	form.WriteField("model", s.config.STTModel)
+	form.WriteField("response_format", "verbose_json")
+	if audio.Language != "" {
+		form.WriteField("language", audio.Language)
+	}
+	if err := form.Close(); err != nil {
+		return nil, fmt.Errorf("speech: build request: %w", err)
+	}
+
+	respBody, err := s.do(ctx, "/audio/transcriptions", form.FormDataContentType(), &body)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp transcriptionResponse
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return nil, fmt.Errorf("speech: decode response: %w", err)
+	}
+
+	// セグメントごとの平均対数確率から信頼度を算出する（セグメント情報がない場合は 0）
+	var weighted, total float64
+	for _, seg := range resp.Segments {
+		duration := math.Max(seg.End-seg.Start, 0.01)
+		weighted += math.Exp(seg.AvgLogprob) * (1 - seg.NoSpeechProb) * duration
+		total += duration
+	}
+	confidence := 0.0
+	if total > 0 {
+		confidence = math.Min(math.Max(weighted/total, 0), 1)
+	}
+
+	language := resp.Language
+	if language == "" {
+		language = audio.Language
+	}
+	return &speech.Transcript{
+		Text:       strings.TrimSpace(resp.Text),
+		Confidence: math.Round(confidence*100) / 100,
+		Language:   language,
+	}, nil
+}
+
+type textToSpeech struct {
+	*client
+}
+
+// NewTextToSpeech は OpenAI 互換の /audio/speech を利用する TextToSpeech を生成します
+func NewTextToSpeech(config Config) speech.TextToSpeech {
+	return &textToSpeech{client: newClient(config)}
+}
+
+type speechRequest struct {
+	Model          string `json:"model"`
+	Input          string `json:"input"`
+	Voice          string `json:"voice"`
+	ResponseFormat string `json:"response_format"`
+}
+
+func (t *textToSpeech) Synthesize(ctx context.Context, req speech.SynthesisRequest) (*speech.SynthesizedAudio, error) {
+	voice := req.Voice
+	if voice == "" {
+		voice = t.config.TTSVoice
+	}
+	payload, err := json.Marshal(speechRequest{
+		Model:          t.config.TTSModel,
+		Input:          req.Text,
+		Voice:          voice,
+		ResponseFormat: "wav",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("speech: marshal request: %w", err)
+	}
+
+	data, err := t.do(ctx, "/audio/speech", "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	return &speech.SynthesizedAudio{Data: data, MimeType: "audio/wav"}, nil
+}
+
+// do はリクエストを送信し、ステータスが 200 の場合にレスポンスボディを返します
+func (c *client) do(ctx context.Context, path, contentType string, body io.Reader) ([]byte, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.config.BaseURL+path, body)
+	if err != nil {
+		return nil, fmt.Errorf("speech: build request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", contentType)
+	if c.config.APIKey != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+c.config.APIKey)
+	}
+
+	resp, err := c.http.Do(httpReq)
+	if err != nil {
+		// キャンセル・期限切れは呼び出し元で判別できるよう ctx のエラーをそのまま伝える
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, fmt.Errorf("speech: %w", ctxErr)
+		}
+		return nil, fmt.Errorf("%w: %v", speech.ErrUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("speech: read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		message := strings.TrimSpace(string(respBody))
+		var errResp struct {
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if json.Unmarshal(respBody, &errResp) == nil && errResp.Error.Message != "" {
+			message = errResp.Error.Message
+		}
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError {
+			return nil, fmt.Errorf("%w: status %d: %s", speech.ErrUnavailable, resp.StatusCode, message)
+		}
+		return nil, fmt.Errorf("speech: status %d: %s", resp.StatusCode, message)
+	}
+	return respBody, nil
+}
@@ -8,10 +8,17 @@ import (
 )
 
 type UseCase interface {
-	GetCompanies(ctx context.Context, page, limit int) (*entity.CompanyResponse, error)
+	GetCompanies(ctx context.Context, query repository.CompanyQuery) (*entity.CompanyResponse, error)
+	// GetCompanySummaries は企業一覧を query.Fields で指定した項目のみの軽量な表現で取得します
+	GetCompanySummaries(ctx context.Context, query repository.CompanyQuery) (*entity.CompanySummaryResponse, error)
+	GetCompanyByID(ctx context.Context, id int) (*entity.Company, error)
 	CreateCompany(ctx context.Context, company *entity.Company) error
+	// UpdateCompany は企業情報を更新します。company.Version が 0 でなければ現在のバージョンと一致する場合のみ更新します。
 	UpdateCompany(ctx context.Context, company *entity.Company) error
-	DeleteCompany(ctx context.Context, id int) error
+	// DeleteCompany は企業情報を削除します。version が 0 でなければ現在のバージョンと一致する場合のみ削除します。
+	DeleteCompany(ctx context.Context, id, version int) error
+	// RestoreCompany はゴミ箱にある企業を元に戻します
+	RestoreCompany(ctx context.Context, id int) (*entity.Company, error)
 }
 
 type usecase struct {
@@ -22,8 +29,16 @@ func NewUseCase(repo repository.CompanyRepository) UseCase {
 	return &usecase{repo: repo}
 }
 
-func (u *usecase) GetCompanies(ctx context.Context, page, limit int) (*entity.CompanyResponse, error) {
-	return u.repo.GetCompanies(ctx, page, limit)
+func (u *usecase) GetCompanies(ctx context.Context, query repository.CompanyQuery) (*entity.CompanyResponse, error) {
+	return u.repo.GetCompanies(ctx, query)
+}
+
+func (u *usecase) GetCompanySummaries(ctx context.Context, query repository.CompanyQuery) (*entity.CompanySummaryResponse, error) {
+	return u.repo.GetCompanySummaries(ctx, query)
+}
+
+func (u *usecase) GetCompanyByID(ctx context.Context, id int) (*entity.Company, error) {
+	return u.repo.GetCompanyByID(ctx, id)
 }
 
 func (u *usecase) CreateCompany(ctx context.Context, company *entity.Company) error {
@@ -34,6 +49,10 @@ func (u *usecase) UpdateCompany(ctx context.Context, company *entity.Company) er
 	return u.repo.UpdateCompany(ctx, company)
 }
 
-func (u *usecase) DeleteCompany(ctx context.Context, id int) error {
-	return u.repo.DeleteCompany(ctx, id)
+func (u *usecase) DeleteCompany(ctx context.Context, id, version int) error {
+	return u.repo.DeleteCompany(ctx, id, version)
+}
+
+func (u *usecase) RestoreCompany(ctx context.Context, id int) (*entity.Company, error) {
+	return u.repo.RestoreCompany(ctx, id)
 }
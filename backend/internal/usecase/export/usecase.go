@@ -0,0 +1,89 @@
+package export
+
+import (
+	"context"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/repository"
+)
+
+// Writer はエクスポートの形式（JSON Lines・CSV・アーカイブ）ごとの書き込み処理です。
+// 表ごとに BeginTable・WriteRow（行の数だけ）・EndTable の順に呼び出されます。行が0件の表も BeginTable・EndTable は呼び出されます。
+type Writer interface {
+	// BeginTable は表の書き込みを開始します。row は表の行の型のゼロ値です（CSV の列名の作成に使用します）。
+	BeginTable(table entity.ExportTable, row interface{}) error
+	WriteRow(table entity.ExportTable, row interface{}) error
+	EndTable(table entity.ExportTable) error
+}
+
+type UseCase interface {
+	// Export は呼び出し元のワークスペースの企業・求人情報・面接の記録を、親の表から順に1行ずつ w に書き込みます。
+	// 全ての表を1つの読み取り専用トランザクションで読み出すため、エクスポート中の更新は含まれません。
+	Export(ctx context.Context, w Writer) error
+}
+
+type usecase struct {
+	transactor repository.Transactor
+	repo       repository.ExportRepository
+}
+
+func NewUseCase(transactor repository.Transactor, repo repository.ExportRepository) UseCase {
+	return &usecase{transactor: transactor, repo: repo}
+}
+
+func (u *usecase) Export(ctx context.Context, w Writer) error {
+	// 表ごとの読み出しの間に更新されても親子の参照が食い違わないよう、同じ時点のスナップショットから読み出す
+	return u.transactor.Snapshot(ctx, func(ctx context.Context) error {
+		return u.export(ctx, w)
+	})
+}
+
+func (u *usecase) export(ctx context.Context, w Writer) error {
+	tables := []struct {
+		table entity.ExportTable
+		row   interface{}
+		each  func(write func(interface{}) error) error
+	}{
+		{entity.ExportTableCompanies, entity.ExportCompany{}, func(write func(interface{}) error) error {
+			return u.repo.EachCompany(ctx, func(row *entity.ExportCompany) error { return write(row) })
+		}},
+		{entity.ExportTableCompanyCustomFields, entity.ExportCompanyCustomField{}, func(write func(interface{}) error) error {
+			return u.repo.EachCompanyCustomField(ctx, func(row *entity.ExportCompanyCustomField) error { return write(row) })
+		}},
+		{entity.ExportTableJobPostings, entity.ExportJobPosting{}, func(write func(interface{}) error) error {
+			return u.repo.EachJobPosting(ctx, func(row *entity.ExportJobPosting) error { return write(row) })
+		}},
+		{entity.ExportTableJobCustomFields, entity.ExportJobCustomField{}, func(write func(interface{}) error) error {
+			return u.repo.EachJobCustomField(ctx, func(row *entity.ExportJobCustomField) error { return write(row) })
+		}},
+		{entity.ExportTableInterviewSessions, entity.ExportInterviewSession{}, func(write func(interface{}) error) error {
+			return u.repo.EachInterviewSession(ctx, func(row *entity.ExportInterviewSession) error { return write(row) })
+		}},
+		{entity.ExportTableInterviewQuestions, entity.ExportInterviewQuestion{}, func(write func(interface{}) error) error {
+			return u.repo.EachInterviewQuestion(ctx, func(row *entity.ExportInterviewQuestion) error { return write(row) })
+		}},
+		{entity.ExportTableInterviewAnswers, entity.ExportInterviewAnswer{}, func(write func(interface{}) error) error {
+			return u.repo.EachInterviewAnswer(ctx, func(row *entity.ExportInterviewAnswer) error { return write(row) })
+		}},
+		{entity.ExportTableInterviewEvaluations, entity.ExportInterviewEvaluation{}, func(write func(interface{}) error) error {
+			return u.repo.EachInterviewEvaluation(ctx, func(row *entity.ExportInterviewEvaluation) error { return write(row) })
+		}},
+		{entity.ExportTableAnswerEvaluations, entity.ExportAnswerEvaluation{}, func(write func(interface{}) error) error {
+			return u.repo.EachAnswerEvaluation(ctx, func(row *entity.ExportAnswerEvaluation) error { return write(row) })
+		}},
+	}
+
+	for _, t := range tables {
+		if err := w.BeginTable(t.table, t.row); err != nil {
+			return err
+		}
+		table := t.table
+		if err := t.each(func(row interface{}) error { return w.WriteRow(table, row) }); err != nil {
+			return err
+		}
+		if err := w.EndTable(t.table); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,283 @@
+package imports
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/apperror"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/repository"
+)
+
+const (
+	// MaxCompanies は1回のインポートで作成できる企業数の上限です
+	MaxCompanies = 500
+	// MaxJobPostings は企業名で紐づける求人情報の取り込みで、1回に作成できる求人情報の上限です
+	MaxJobPostings = 500
+)
+
+// errRollback は確認のみの実行・エラーのある行がある場合にトランザクションをロールバックするためのエラーです
+var errRollback = errors.New("rollback import")
+
+type UseCase interface {
+	// Import は企業と求人情報を1つのトランザクションで作成します。1件でも作成できない行がある場合は何も作成しません。
+	// dryRun の場合は作成した後に必ずロールバックし、重複なども含めた各行のエラーを返します。
+	// rowErrors は呼び出し元で検出した行のエラーで、結果のエラーに含めます（ある場合は作成しません）。
+	Import(ctx context.Context, companies []entity.ImportCompany, rowErrors []apperror.Detail, dryRun bool) (*entity.ImportResult, error)
+	// ImportArchive はエクスポートのアーカイブの企業・求人情報と面接の記録を1つのトランザクションで作成します。
+	// 面接セッションの企業・求人情報の参照は作成した企業・求人情報の ID に付け替えます（アーカイブにない場合は紐付けません）。
+	// 企業数の上限（MaxCompanies）はなく、アーカイブのサイズのみで制限します。dryRun・rowErrors の扱いは Import と同じです。
+	ImportArchive(ctx context.Context, archive *entity.ImportArchive, rowErrors []apperror.Detail, dryRun bool) (*entity.ImportResult, error)
+	// ImportJobPostings は求人情報を企業名で既存の企業に紐づけ、1つのトランザクションで作成します。一致する企業がない場合は企業も作成します。
+	// dryRun・rowErrors の扱いは Import と同じです。
+	ImportJobPostings(ctx context.Context, items []entity.ImportLinkedJobPosting, rowErrors []apperror.Detail, dryRun bool) (*entity.LinkedImportResult, error)
+}
+
+type usecase struct {
+	transactor     repository.Transactor
+	companyRepo    repository.CompanyRepository
+	jobPostingRepo repository.JobPostingRepository
+	sessionRepo    repository.InterviewSessionRepository
+}
+
+func NewUseCase(
+	transactor repository.Transactor,
+	companyRepo repository.CompanyRepository,
+	jobPostingRepo repository.JobPostingRepository,
+	sessionRepo repository.InterviewSessionRepository,
+) UseCase {
+	return &usecase{transactor: transactor, companyRepo: companyRepo, jobPostingRepo: jobPostingRepo, sessionRepo: sessionRepo}
+}
+
+func (u *usecase) Import(ctx context.Context, companies []entity.ImportCompany, rowErrors []apperror.Detail, dryRun bool) (*entity.ImportResult, error) {
+	if len(companies) > MaxCompanies {
+		return nil, apperror.Validation(fmt.Sprintf("too many companies to import (max %d)", MaxCompanies),
+			apperror.Detail{Field: "rows", Message: fmt.Sprintf("must contain at most %d companies", MaxCompanies)})
+	}
+
+	return u.ImportArchive(ctx, &entity.ImportArchive{Companies: companies}, rowErrors, dryRun)
+}
+
+func (u *usecase) ImportArchive(ctx context.Context, archive *entity.ImportArchive, rowErrors []apperror.Detail, dryRun bool) (*entity.ImportResult, error) {
+	var result *entity.ImportResult
+	err := u.transactor.Transaction(ctx, func(ctx context.Context) error {
+		result = &entity.ImportResult{DryRun: dryRun, Errors: append([]apperror.Detail{}, rowErrors...)}
+		ids := newSourceIDs()
+		for _, item := range archive.Companies {
+			if err := u.importCompany(ctx, item, ids, result); err != nil {
+				return err
+			}
+		}
+		for _, item := range archive.Sessions {
+			if err := u.importSession(ctx, item, ids, result); err != nil {
+				return err
+			}
+		}
+		if dryRun || len(result.Errors) > 0 {
+			return errRollback
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errRollback) {
+		return nil, err
+	}
+
+	if !dryRun && len(result.Errors) > 0 {
+		return nil, apperror.Unprocessable("import contains invalid rows; nothing was imported", result.Errors...)
+	}
+	return result, nil
+}
+
+// sourceIDs はアーカイブでの企業・求人情報の ID から作成した ID への対応です
+type sourceIDs struct {
+	companies   map[int]int
+	jobPostings map[int]int
+}
+
+func newSourceIDs() *sourceIDs {
+	return &sourceIDs{companies: map[int]int{}, jobPostings: map[int]int{}}
+}
+
+// remap はアーカイブでの ID を作成した ID に付け替えます。アーカイブにない（作成していない）場合は nil を返します。
+func remap(ids map[int]int, sourceID *int) *int {
+	if sourceID == nil {
+		return nil
+	}
+	id, ok := ids[*sourceID]
+	if !ok {
+		return nil
+	}
+	return &id
+}
+
+// importCompany は企業とその求人情報を作成し、SourceID から作成した ID への対応を ids に記録します。
+// 入力の内容による作成の失敗（重複など）は result.Errors に追加して続行し、それ以外のエラーのみを返します。
+func (u *usecase) importCompany(ctx context.Context, item entity.ImportCompany, ids *sourceIDs, result *entity.ImportResult) error {
+	company := item.Company
+	company.JobPostings = nil
+	if err := u.companyRepo.CreateCompany(ctx, &company); err != nil {
+		return addRowError(&result.Errors, item.Row, "", err)
+	}
+	result.Companies++
+	if item.SourceID != 0 {
+		ids.companies[item.SourceID] = company.ID
+	}
+
+	for _, job := range item.JobPostings {
+		jobPosting := job.JobPosting
+		jobPosting.CompanyID = company.ID
+		if _, err := u.jobPostingRepo.CreateJobPosting(ctx, &jobPosting); err != nil {
+			if err := addRowError(&result.Errors, job.Row, job.Path, err); err != nil {
+				return err
+			}
+			continue
+		}
+		result.JobPostings++
+		if job.SourceID != 0 {
+			ids.jobPostings[job.SourceID] = jobPosting.ID
+		}
+	}
+	return nil
+}
+
+// importSession は面接セッションをその質問・回答・評価ごと作成します。エラーの扱いは importCompany と同じです。
+func (u *usecase) importSession(ctx context.Context, item entity.ImportSession, ids *sourceIDs, result *entity.ImportResult) error {
+	session := item.Session
+	session.CompanyID = remap(ids.companies, session.CompanyID)
+	session.JobPostingID = remap(ids.jobPostings, session.JobPostingID)
+	if err := u.sessionRepo.ImportSession(ctx, &session, item.Evaluation); err != nil {
+		details, err := FieldErrors(fmt.Sprintf("interview_sessions[%d]", item.Row), err)
+		result.Errors = append(result.Errors, details...)
+		return err
+	}
+	result.InterviewSessions++
+	return nil
+}
+
+func (u *usecase) ImportJobPostings(ctx context.Context, items []entity.ImportLinkedJobPosting, rowErrors []apperror.Detail, dryRun bool) (*entity.LinkedImportResult, error) {
+	if len(items) > MaxJobPostings {
+		return nil, apperror.Validation(fmt.Sprintf("too many job postings to import (max %d)", MaxJobPostings),
+			apperror.Detail{Field: "rows", Message: fmt.Sprintf("must contain at most %d job postings", MaxJobPostings)})
+	}
+
+	var result *entity.LinkedImportResult
+	err := u.transactor.Transaction(ctx, func(ctx context.Context) error {
+		result = &entity.LinkedImportResult{
+			DryRun: dryRun,
+			Items:  []entity.LinkedJobPostingResult{},
+			Errors: append([]apperror.Detail{}, rowErrors...),
+		}
+		for _, item := range items {
+			if err := u.importLinkedJobPosting(ctx, item, result); err != nil {
+				return err
+			}
+		}
+		if dryRun || len(result.Errors) > 0 {
+			return errRollback
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errRollback) {
+		return nil, err
+	}
+
+	if !dryRun && len(result.Errors) > 0 {
+		return nil, apperror.Unprocessable("import contains invalid rows; nothing was imported", result.Errors...)
+	}
+	if dryRun {
+		// ロールバックした企業・求人情報の ID は返さない
+		for i := range result.Items {
+			result.Items[i].JobPostingID = 0
+			if result.Items[i].CompanyCreated {
+				result.Items[i].CompanyID = 0
+			}
+		}
+	}
+	return result, nil
+}
+
+// importLinkedJobPosting は求人情報を紐づける企業を決め（なければ作成し）、求人情報を作成します。
+// 入力の内容による作成の失敗は result.Errors に追加して続行し、それ以外のエラーのみを返します。
+func (u *usecase) importLinkedJobPosting(ctx context.Context, item entity.ImportLinkedJobPosting, result *entity.LinkedImportResult) error {
+	jobPosting := item.JobPosting
+	itemResult := entity.LinkedJobPostingResult{
+		Row:               item.Row,
+		Title:             jobPosting.Title,
+		IgnoredProperties: item.Ignored,
+	}
+
+	if jobPosting.CompanyID != 0 {
+		company, err := u.companyRepo.GetCompanyByID(ctx, jobPosting.CompanyID)
+		if err != nil {
+			return addRowError(&result.Errors, item.Row, "company_id", err)
+		}
+		itemResult.CompanyName = company.Name
+	} else {
+		company, err := u.companyRepo.GetCompanyByName(ctx, item.Company.Name)
+		switch {
+		case err == nil:
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			company = &item.Company
+			company.JobPostings = nil
+			if err := u.companyRepo.CreateCompany(ctx, company); err != nil {
+				return addRowError(&result.Errors, item.Row, "company", err)
+			}
+			itemResult.CompanyCreated = true
+			result.Companies++
+		default:
+			return err
+		}
+		jobPosting.CompanyID = company.ID
+		itemResult.CompanyName = company.Name
+	}
+	itemResult.CompanyID = jobPosting.CompanyID
+
+	if _, err := u.jobPostingRepo.CreateJobPosting(ctx, &jobPosting); err != nil {
+		return addRowError(&result.Errors, item.Row, "", err)
+	}
+	itemResult.JobPostingID = jobPosting.ID
+	result.JobPostings++
+	result.Items = append(result.Items, itemResult)
+	return nil
+}
+
+// addRowError は行の内容によるエラーを errs に追加します。入力によらないエラーはそのまま返します。
+func addRowError(errs *[]apperror.Detail, row int, path string, err error) error {
+	details, err := RowErrors(row, path, err)
+	*errs = append(*errs, details...)
+	return err
+}
+
+// RowErrors は行の内容によるエラーを rows[行].項目 の形式の詳細に変換します。
+// 内部エラー・タイムアウトなど入力によらないエラーの場合は、詳細を返さずにエラーをそのまま返します。
+func RowErrors(row int, path string, err error) ([]apperror.Detail, error) {
+	prefix := fmt.Sprintf("rows[%d]", row)
+	if path != "" {
+		prefix += "." + path
+	}
+	return FieldErrors(prefix, err)
+}
+
+// FieldErrors は入力の内容によるエラーを prefix.項目 の形式の詳細に変換します。入力によらないエラーの扱いは RowErrors と同じです。
+func FieldErrors(prefix string, err error) ([]apperror.Detail, error) {
+	if errors.Is(err, context.Canceled) {
+		return nil, err
+	}
+	appErr := apperror.From(err)
+	switch appErr.Code {
+	case apperror.CodeInternal, apperror.CodeTimeout, apperror.CodeUnauthorized, apperror.CodeForbidden:
+		return nil, err
+	}
+
+	if len(appErr.Details) == 0 {
+		return []apperror.Detail{{Field: prefix, Message: appErr.Message}}, nil
+	}
+	details := make([]apperror.Detail, 0, len(appErr.Details))
+	for _, detail := range appErr.Details {
+		details = append(details, apperror.Detail{Field: prefix + "." + detail.Field, Message: detail.Message})
+	}
+	return details, nil
+}
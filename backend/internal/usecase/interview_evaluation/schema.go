@@ -0,0 +1,137 @@
+package interview_evaluation
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/llm"
+)
+
+// answerEvaluationSchema は回答評価の出力を制約する JSON スキーマです
+var answerEvaluationSchema = &llm.JSONSchema{
+	Name:   "answer_evaluation",
+	Strict: true,
+	Schema: json.RawMessage(`{
+  "type": "object",
+  "properties": {
+    "logical_score": {"type": "integer", "minimum": 0, "maximum": 100},
+    "communication_score": {"type": "integer", "minimum": 0, "maximum": 100},
+    "technical_score": {"type": "integer", "minimum": 0, "maximum": 100},
+    "problem_solving_score": {"type": "integer", "minimum": 0, "maximum": 100},
+    "motivation_score": {"type": "integer", "minimum": 0, "maximum": 100},
+    "culture_fit_score": {"type": "integer", "minimum": 0, "maximum": 100},
+    "question_comment": {"type": "string"},
+    "strengths": {"type": "array", "items": {"type": "string"}},
+    "improvements": {"type": "array", "items": {"type": "string"}}
+  },
+  "required": ["logical_score", "communication_score", "technical_score", "problem_solving_score", "motivation_score", "culture_fit_score", "question_comment", "strengths", "improvements"],
+  "additionalProperties": false
+}`),
+}
+
+// overallEvaluationSchema は総評の出力を制約する JSON スキーマです
+var overallEvaluationSchema = &llm.JSONSchema{
+	Name:   "overall_evaluation",
+	Strict: true,
+	Schema: json.RawMessage(`{
+  "type": "object",
+  "properties": {
+    "overall_comment": {"type": "string"}
+  },
+  "required": ["overall_comment"],
+  "additionalProperties": false
+}`),
+}
+
+// answerEvaluationOutput は回答評価の LLM 出力です。必須項目の欠落を検出するためポインタで受け取ります。
+type answerEvaluationOutput struct {
+	LogicalScore        *int      `json:"logical_score"`
+	CommunicationScore  *int      `json:"communication_score"`
+	TechnicalScore      *int      `json:"technical_score"`
+	ProblemSolvingScore *int      `json:"problem_solving_score"`
+	MotivationScore     *int      `json:"motivation_score"`
+	CultureFitScore     *int      `json:"culture_fit_score"`
+	QuestionComment     *string   `json:"question_comment"`
+	Strengths           *[]string `json:"strengths"`
+	Improvements        *[]string `json:"improvements"`
+}
+
+type overallEvaluationOutput struct {
+	OverallComment *string `json:"overall_comment"`
+}
+
+// parseAnswerEvaluation は LLM の出力を検証し、回答評価に変換します
+func parseAnswerEvaluation(content string) (*entity.AnswerEvaluation, error) {
+	var out answerEvaluationOutput
+	if err := decodeStrict(content, &out); err != nil {
+		return nil, err
+	}
+
+	scores := []struct {
+		name  string
+		value *int
+	}{
+		{"logical_score", out.LogicalScore},
+		{"communication_score", out.CommunicationScore},
+		{"technical_score", out.TechnicalScore},
+		{"problem_solving_score", out.ProblemSolvingScore},
+		{"motivation_score", out.MotivationScore},
+		{"culture_fit_score", out.CultureFitScore},
+	}
+	for _, s := range scores {
+		if s.value == nil {
+			return nil, fmt.Errorf("%w: %s is required", ErrInvalidEvaluation, s.name)
+		}
+		if *s.value < 0 || *s.value > 100 {
+			return nil, fmt.Errorf("%w: %s must be between 0 and 100", ErrInvalidEvaluation, s.name)
+		}
+	}
+	if out.QuestionComment == nil || strings.TrimSpace(*out.QuestionComment) == "" {
+		return nil, fmt.Errorf("%w: question_comment is required", ErrInvalidEvaluation)
+	}
+	if out.Strengths == nil {
+		return nil, fmt.Errorf("%w: strengths is required", ErrInvalidEvaluation)
+	}
+	if out.Improvements == nil {
+		return nil, fmt.Errorf("%w: improvements is required", ErrInvalidEvaluation)
+	}
+
+	return &entity.AnswerEvaluation{
+		EvaluationScores: entity.EvaluationScores{
+			LogicalScore:        *out.LogicalScore,
+			CommunicationScore:  *out.CommunicationScore,
+			TechnicalScore:      *out.TechnicalScore,
+			ProblemSolvingScore: *out.ProblemSolvingScore,
+			MotivationScore:     *out.MotivationScore,
+			CultureFitScore:     *out.CultureFitScore,
+		},
+		QuestionComment: *out.QuestionComment,
+		Strengths:       entity.StringList(*out.Strengths),
+		Improvements:    entity.StringList(*out.Improvements),
+	}, nil
+}
+
+// parseOverallComment は LLM の出力を検証し、総評コメントを取り出します
+func parseOverallComment(content string) (string, error) {
+	var out overallEvaluationOutput
+	if err := decodeStrict(content, &out); err != nil {
+		return "", err
+	}
+	if out.OverallComment == nil || strings.TrimSpace(*out.OverallComment) == "" {
+		return "", fmt.Errorf("%w: overall_comment is required", ErrInvalidEvaluation)
+	}
+	return *out.OverallComment, nil
+}
+
+// decodeStrict はスキーマ外のプロパティを許容せずに JSON をデコードします
+func decodeStrict(content string, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader([]byte(strings.TrimSpace(content))))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidEvaluation, err)
+	}
+	return nil
+}
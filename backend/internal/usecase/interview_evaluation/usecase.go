@@ -0,0 +1,380 @@
+package interview_evaluation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/apperror"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/auth"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/llm"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/repository"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/prompt"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/worker"
+)
+
+// JobTypeEvaluation は面接評価ジョブの種別です
+const JobTypeEvaluation = "interview_evaluation"
+
+// DefaultCallTimeout は評価生成の LLM 呼び出し1回あたりに見込む時間の既定値です
+const DefaultCallTimeout = 30 * time.Second
+
+var (
+	// ErrInvalidStatus はセッションが評価可能な状態（COMPLETED）でない場合に返されます
+	ErrInvalidStatus = apperror.Conflict("interview session is not completed")
+	// ErrNoAnswers は評価対象の回答が1件も存在しない場合に返されます
+	ErrNoAnswers = apperror.Conflict("interview session has no answers to evaluate")
+	// ErrInvalidEvaluation は LLM の出力が期待するスキーマに従っていない場合に返されます
+	ErrInvalidEvaluation = apperror.UpstreamError("invalid evaluation output")
+)
+
+type UseCase interface {
+	// RequestEvaluation はセッションが評価可能であることを確認し、評価ジョブを登録します
+	RequestEvaluation(ctx context.Context, sessionID int) (*entity.Job, error)
+	// HandleEvaluationJob は評価ジョブを処理します（ワーカーから呼び出されます）
+	HandleEvaluationJob(ctx context.Context, job *entity.Job) (interface{}, error)
+	Evaluate(ctx context.Context, sessionID int) (*entity.InterviewEvaluation, error)
+	GetEvaluation(ctx context.Context, sessionID int) (*entity.InterviewEvaluation, error)
+}
+
+// JobQueue は評価ジョブの登録先です
+type JobQueue interface {
+	Enqueue(ctx context.Context, jobType string, payload interface{}, timeout time.Duration) (*entity.Job, error)
+}
+
+// evaluationJobPayload は評価ジョブのペイロードです
+type evaluationJobPayload struct {
+	SessionID int `json:"session_id"`
+	// UserID は評価を依頼したユーザーです。ワーカーはこのユーザーとして企業・求人情報を参照します。
+	UserID int `json:"user_id"`
+	// OrganizationID は依頼時のワークスペースの組織です（個人のワークスペースの場合は nil）
+	OrganizationID *int `json:"organization_id,omitempty"`
+}
+
+// evaluationJobResult は評価ジョブの結果です
+type evaluationJobResult struct {
+	SessionID    int                   `json:"session_id"`
+	EvaluationID int                   `json:"evaluation_id"`
+	TotalRank    entity.EvaluationRank `json:"total_rank"`
+	TotalScore   int                   `json:"total_score"`
+}
+
+type usecase struct {
+	repo           repository.InterviewEvaluationRepository
+	sessionRepo    repository.InterviewSessionRepository
+	companyRepo    repository.CompanyRepository
+	jobPostingRepo repository.JobPostingRepository
+	provider       llm.Provider
+	prompts        *prompt.Registry
+	queue          JobQueue
+	callTimeout    time.Duration
+}
+
+// NewUseCase は評価のユースケースを作成します。callTimeout は LLM 呼び出し1回あたりに見込む時間で、評価ジョブのタイムアウトは呼び出し回数に比例させます。
+func NewUseCase(
+	repo repository.InterviewEvaluationRepository,
+	sessionRepo repository.InterviewSessionRepository,
+	companyRepo repository.CompanyRepository,
+	jobPostingRepo repository.JobPostingRepository,
+	provider llm.Provider,
+	prompts *prompt.Registry,
+	queue JobQueue,
+	callTimeout time.Duration,
+) UseCase {
+	return &usecase{
+		repo:           repo,
+		sessionRepo:    sessionRepo,
+		companyRepo:    companyRepo,
+		jobPostingRepo: jobPostingRepo,
+		provider:       provider,
+		prompts:        prompts,
+		queue:          queue,
+		callTimeout:    callTimeout,
+	}
+}
+
+func (u *usecase) RequestEvaluation(ctx context.Context, sessionID int) (*entity.Job, error) {
+	if u.provider == nil {
+		return nil, fmt.Errorf("%w: provider is not configured", llm.ErrUnavailable)
+	}
+
+	// 呼び出し元のセッションであることを確認してから登録する（他のユーザーのセッションは 404 Not Found）
+	session, err := u.sessionRepo.GetSession(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session.Status != entity.InterviewSessionStatusCompleted {
+		return nil, ErrInvalidStatus
+	}
+
+	userID, err := auth.UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	payload := evaluationJobPayload{SessionID: sessionID, UserID: userID}
+	if membership, ok := auth.MembershipFromContext(ctx); ok {
+		organizationID := membership.OrganizationID
+		payload.OrganizationID = &organizationID
+	}
+
+	// 回答ごとの評価とセッション全体の評価で、LLM を (回答数 + 1) 回呼び出す
+	answered := 0
+	for _, q := range session.Questions {
+		if q.Answer != nil {
+			answered++
+		}
+	}
+	if answered == 0 {
+		return nil, ErrNoAnswers
+	}
+	timeout := u.callTimeout * time.Duration(answered+1)
+	return u.queue.Enqueue(ctx, JobTypeEvaluation, payload, timeout)
+}
+
+func (u *usecase) HandleEvaluationJob(ctx context.Context, job *entity.Job) (interface{}, error) {
+	var payload evaluationJobPayload
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		return nil, worker.Permanent(err)
+	}
+
+	ctx = auth.WithUser(ctx, &entity.User{ID: payload.UserID})
+	if payload.OrganizationID != nil {
+		// 企業・求人情報の参照のみに使用するため、ロールは問わない
+		ctx = auth.WithMembership(ctx, &entity.Membership{OrganizationID: *payload.OrganizationID, UserID: payload.UserID})
+	}
+	evaluation, err := u.Evaluate(ctx, payload.SessionID)
+	if err != nil {
+		// リトライしても結果が変わらないエラーは即座に失敗とする
+		if errors.Is(err, gorm.ErrRecordNotFound) ||
+			errors.Is(err, ErrInvalidStatus) ||
+			errors.Is(err, ErrNoAnswers) {
+			return nil, worker.Permanent(err)
+		}
+		return nil, err
+	}
+
+	return evaluationJobResult{
+		SessionID:    evaluation.SessionID,
+		EvaluationID: evaluation.ID,
+		TotalRank:    evaluation.TotalRank,
+		TotalScore:   evaluation.TotalScore,
+	}, nil
+}
+
+func (u *usecase) GetEvaluation(ctx context.Context, sessionID int) (*entity.InterviewEvaluation, error) {
+	return u.repo.GetEvaluationBySession(ctx, sessionID)
+}
+
+func (u *usecase) Evaluate(ctx context.Context, sessionID int) (*entity.InterviewEvaluation, error) {
+	if u.provider == nil {
+		return nil, fmt.Errorf("%w: provider is not configured", llm.ErrUnavailable)
+	}
+
+	session, err := u.sessionRepo.GetSession(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session.Status != entity.InterviewSessionStatusCompleted {
+		return nil, ErrInvalidStatus
+	}
+
+	companyDetails, jobPostingDetails, err := u.contextDetails(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+
+	// 1. 回答単位の評価生成
+	var answers []entity.AnswerEvaluation
+	var results []evaluationResult
+	for _, q := range session.Questions {
+		if q.Answer == nil {
+			continue
+		}
+		answer, err := u.evaluateAnswer(ctx, prompt.AnswerEvaluationData{
+			CompanyDetails:    companyDetails,
+			JobPostingDetails: jobPostingDetails,
+			InterviewPhase:    stringValue(session.InterviewPhase),
+			QuestionContent:   q.Content,
+			AnswerContent:     q.Answer.Content,
+		})
+		if err != nil {
+			return nil, err
+		}
+		answer.QuestionID = q.ID
+		answers = append(answers, *answer)
+		results = append(results, evaluationResult{
+			Question:     q.Content,
+			Scores:       answer.EvaluationScores,
+			Strengths:    answer.Strengths,
+			Improvements: answer.Improvements,
+		})
+	}
+	if len(answers) == 0 {
+		return nil, ErrNoAnswers
+	}
+
+	// 2. セッション全体の評価生成
+	scores := averageScores(answers)
+	totalScore := totalScore(scores)
+	evaluation := &entity.InterviewEvaluation{
+		SessionID:         session.ID,
+		TotalRank:         rankForScore(totalScore),
+		TotalScore:        totalScore,
+		Scores:            scores,
+		AnswerEvaluations: answers,
+	}
+
+	resultsJSON, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	evaluation.OverallComment, err = u.overallComment(ctx, prompt.OverallEvaluationData{
+		CompanyDetails:    companyDetails,
+		JobPostingDetails: jobPostingDetails,
+		InterviewPhase:    stringValue(session.InterviewPhase),
+		Results:           string(resultsJSON),
+		Scores:            scores,
+		TotalScore:        totalScore,
+		TotalRank:         evaluation.TotalRank,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.repo.SaveEvaluation(ctx, evaluation); err != nil {
+		if errors.Is(err, repository.ErrSessionStatusConflict) {
+			return nil, ErrInvalidStatus
+		}
+		return nil, err
+	}
+
+	return evaluation, nil
+}
+
+// evaluationResult は総評生成プロンプトに渡す回答評価結果です
+type evaluationResult struct {
+	Question     string                  `json:"question"`
+	Scores       entity.EvaluationScores `json:"scores"`
+	Strengths    []string                `json:"strengths"`
+	Improvements []string                `json:"improvements"`
+}
+
+func (u *usecase) evaluateAnswer(ctx context.Context, data prompt.AnswerEvaluationData) (*entity.AnswerEvaluation, error) {
+	content, err := u.complete(ctx, prompt.AnswerEvaluation, data, answerEvaluationSchema)
+	if err != nil {
+		return nil, err
+	}
+	return parseAnswerEvaluation(content)
+}
+
+func (u *usecase) overallComment(ctx context.Context, data prompt.OverallEvaluationData) (string, error) {
+	content, err := u.complete(ctx, prompt.OverallEvaluation, data, overallEvaluationSchema)
+	if err != nil {
+		return "", err
+	}
+	return parseOverallComment(content)
+}
+
+func (u *usecase) complete(ctx context.Context, templateName string, data interface{}, schema *llm.JSONSchema) (string, error) {
+	text, err := u.prompts.Render(templateName, data)
+	if err != nil {
+		return "", err
+	}
+	resp, err := u.provider.Complete(ctx, llm.CompletionRequest{
+		Messages:   []llm.Message{llm.UserMessage(text)},
+		JSONSchema: schema,
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.Content, nil
+}
+
+// contextDetails はセッションに紐づく企業・求人情報をプロンプト用に整形します
+func (u *usecase) contextDetails(ctx context.Context, session *entity.InterviewSession) (string, string, error) {
+	var company *entity.Company
+	var jobPosting *entity.JobPosting
+	var err error
+
+	if session.CompanyID != nil {
+		company, err = u.companyRepo.GetCompanyByID(ctx, *session.CompanyID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", "", err
+		}
+	}
+	if session.JobPostingID != nil {
+		jobPosting, err = u.jobPostingRepo.GetJobPosting(ctx, *session.JobPostingID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", "", err
+		}
+	}
+
+	return prompt.CompanyDetails(company, prompt.DefaultContextMaxRunes),
+		prompt.JobPostingDetails(jobPosting, prompt.DefaultContextMaxRunes),
+		nil
+}
+
+// averageScores は回答評価の各評価軸の単純平均を返します
+func averageScores(answers []entity.AnswerEvaluation) entity.EvaluationScores {
+	var sum entity.EvaluationScores
+	for _, a := range answers {
+		sum.LogicalScore += a.LogicalScore
+		sum.CommunicationScore += a.CommunicationScore
+		sum.TechnicalScore += a.TechnicalScore
+		sum.ProblemSolvingScore += a.ProblemSolvingScore
+		sum.MotivationScore += a.MotivationScore
+		sum.CultureFitScore += a.CultureFitScore
+	}
+	n := len(answers)
+	return entity.EvaluationScores{
+		LogicalScore:        roundedAverage(sum.LogicalScore, n),
+		CommunicationScore:  roundedAverage(sum.CommunicationScore, n),
+		TechnicalScore:      roundedAverage(sum.TechnicalScore, n),
+		ProblemSolvingScore: roundedAverage(sum.ProblemSolvingScore, n),
+		MotivationScore:     roundedAverage(sum.MotivationScore, n),
+		CultureFitScore:     roundedAverage(sum.CultureFitScore, n),
+	}
+}
+
+// totalScore は6つの評価軸の単純平均を返します
+func totalScore(s entity.EvaluationScores) int {
+	sum := s.LogicalScore + s.CommunicationScore + s.TechnicalScore +
+		s.ProblemSolvingScore + s.MotivationScore + s.CultureFitScore
+	return roundedAverage(sum, 6)
+}
+
+// rankForScore は総合スコアからランクを判定します（設計書 2.3.2 参照）
+func rankForScore(score int) entity.EvaluationRank {
+	switch {
+	case score >= 90:
+		return entity.EvaluationRankA
+	case score >= 80:
+		return entity.EvaluationRankB
+	case score >= 70:
+		return entity.EvaluationRankC
+	case score >= 60:
+		return entity.EvaluationRankD
+	default:
+		return entity.EvaluationRankE
+	}
+}
+
+func roundedAverage(sum, n int) int {
+	if n == 0 {
+		return 0
+	}
+	return int(math.Round(float64(sum) / float64(n)))
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
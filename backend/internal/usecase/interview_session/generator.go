@@ -0,0 +1,199 @@
+package interview_session
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/llm"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/prompt"
+)
+
+const (
+	// 一時的に定数で設定（設計書 5.1 参照）
+	interviewerName = "面接子"
+	applicantName   = "髙野晃"
+)
+
+// GenerateInput は質問生成に必要な情報です
+type GenerateInput struct {
+	Session *entity.InterviewSession
+	// Company と JobPosting はセッションで指定されていない場合 nil になります
+	Company    *entity.Company
+	JobPosting *entity.JobPosting
+	Status     entity.InterviewSessionStatus
+	// OnDelta が指定されている場合、生成中の発話を逐次受け取ります
+	OnDelta llm.DeltaFunc
+}
+
+// GeneratedQuestion は生成された質問です
+type GeneratedQuestion struct {
+	Content string
+	// PromptVersion は生成に使用したプロンプトのバージョンです。プロンプトを使用しない場合は空になります。
+	PromptVersion string
+}
+
+// QuestionGenerator は面接フェーズに応じた面接官の発話を生成します
+type QuestionGenerator interface {
+	// Generate はセッションの質問履歴を踏まえ、input.Status のフェーズで提示する質問を返します
+	Generate(ctx context.Context, input GenerateInput) (*GeneratedQuestion, error)
+}
+
+type staticQuestionGenerator struct{}
+
+// NewStaticQuestionGenerator は固定文言で質問を返すジェネレーターを生成します
+func NewStaticQuestionGenerator() QuestionGenerator {
+	return &staticQuestionGenerator{}
+}
+
+var iceBreakQuestions = []string{
+	"最近、お仕事や学業の中で特に充実していると感じることはありますか？",
+	"この業界に興味を持ったきっかけについて教えていただけますか？",
+}
+
+var mainQuestions = []string{
+	"これまでのご経験の中で、最も力を入れて取り組んだ業務について具体的に教えてください。",
+	"弊社を志望された理由を教えてください。",
+	"チームで意見が対立した際、どのように対処されましたか？",
+	"業務上の課題を発見し、改善に取り組んだ経験があれば教えてください。",
+	"仕事をするうえで大切にしている価値観を教えてください。",
+	"これまでに経験した失敗と、そこから学んだことを教えてください。",
+	"5年後にどのようなキャリアを築いていたいとお考えですか？",
+	"ストレスを感じたとき、どのように対処されていますか？",
+	"複数のタスクを並行して進める際に工夫していることを教えてください。",
+	"最後に、何かご質問はありますか？",
+}
+
+func (g *staticQuestionGenerator) Generate(ctx context.Context, input GenerateInput) (*GeneratedQuestion, error) {
+	content, err := g.content(input.Session, input.Status)
+	if err != nil {
+		return nil, err
+	}
+	if input.OnDelta != nil {
+		if err := input.OnDelta(content); err != nil {
+			return nil, err
+		}
+	}
+	return &GeneratedQuestion{Content: content}, nil
+}
+
+func (g *staticQuestionGenerator) content(session *entity.InterviewSession, status entity.InterviewSessionStatus) (string, error) {
+	switch status {
+	case entity.InterviewSessionStatusGreeting:
+		role := "面接官"
+		if session.InterviewerRole != nil && *session.InterviewerRole != "" {
+			role = *session.InterviewerRole
+		}
+		return fmt.Sprintf("はじめまして。本日は面接にお時間をいただき、ありがとうございます。私は%sの%sと申します。よろしくお願いいたします。", role, interviewerName), nil
+	case entity.InterviewSessionStatusSelfIntroduction:
+		return fmt.Sprintf("それでは%s様、簡単に自己紹介をお願いできますでしょうか？", applicantName), nil
+	case entity.InterviewSessionStatusIceBreak:
+		return iceBreakQuestions[session.ID%len(iceBreakQuestions)], nil
+	case entity.InterviewSessionStatusMain:
+		// 既に提示した主質問の数に応じて順番に出題する
+		asked := 0
+		for _, q := range session.Questions {
+			if q.Sequence > preMainQuestionCount(session) {
+				asked++
+			}
+		}
+		return mainQuestions[asked%len(mainQuestions)], nil
+	default:
+		return "", fmt.Errorf("unsupported interview status: %s", status)
+	}
+}
+
+type llmQuestionGenerator struct {
+	provider llm.Provider
+	prompts  *prompt.Registry
+}
+
+// NewLLMQuestionGenerator は prompts のテンプレートを用いて LLM で質問を生成するジェネレーターを生成します
+func NewLLMQuestionGenerator(provider llm.Provider, prompts *prompt.Registry) QuestionGenerator {
+	return &llmQuestionGenerator{provider: provider, prompts: prompts}
+}
+
+// phaseTemplates は各フェーズ固有のプロンプトテンプレート名です（設計書 5.2 参照）
+var phaseTemplates = map[entity.InterviewSessionStatus]string{
+	entity.InterviewSessionStatusGreeting:         prompt.InterviewGreeting,
+	entity.InterviewSessionStatusSelfIntroduction: prompt.InterviewSelfIntroduction,
+	entity.InterviewSessionStatusIceBreak:         prompt.InterviewIceBreak,
+	entity.InterviewSessionStatusMain:             prompt.InterviewMain,
+}
+
+func (g *llmQuestionGenerator) Generate(ctx context.Context, input GenerateInput) (*GeneratedQuestion, error) {
+	phaseTemplate, ok := phaseTemplates[input.Status]
+	if !ok {
+		return nil, fmt.Errorf("unsupported interview status: %s", input.Status)
+	}
+
+	data := interviewPromptData(input)
+	systemPrompt, err := g.prompts.Render(prompt.InterviewSystem, data)
+	if err != nil {
+		return nil, err
+	}
+	phasePrompt, err := g.prompts.Render(phaseTemplate, data)
+	if err != nil {
+		return nil, err
+	}
+
+	req := llm.CompletionRequest{
+		Messages: []llm.Message{
+			llm.SystemMessage(systemPrompt),
+			llm.UserMessage(phasePrompt),
+		},
+	}
+	var resp *llm.CompletionResponse
+	if input.OnDelta != nil {
+		resp, err = llm.Stream(ctx, g.provider, req, input.OnDelta)
+	} else {
+		resp, err = g.provider.Complete(ctx, req)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &GeneratedQuestion{
+		Content:       strings.Trim(strings.TrimSpace(resp.Content), "「」"),
+		PromptVersion: g.prompts.Version(),
+	}, nil
+}
+
+// interviewPromptData はプロンプトテンプレートに渡すデータを組み立てます
+func interviewPromptData(input GenerateInput) prompt.InterviewData {
+	session := input.Session
+	data := prompt.InterviewData{
+		CompanyDetails:    prompt.CompanyDetails(input.Company, prompt.DefaultContextMaxRunes),
+		JobPostingDetails: prompt.JobPostingDetails(input.JobPosting, prompt.DefaultContextMaxRunes),
+		InterviewPhase:    stringValue(session.InterviewPhase),
+		InterviewerRole:   stringValue(session.InterviewerRole),
+		QuestionCount:     session.QuestionCount,
+		InterviewerName:   interviewerName,
+		ApplicantName:     applicantName,
+	}
+	if input.Company != nil {
+		data.CompanyName = input.Company.Name
+	}
+
+	// 挨拶フェーズ以外では質問履歴を渡す
+	if input.Status != entity.InterviewSessionStatusGreeting {
+		var history []prompt.HistoryItem
+		for _, q := range session.Questions {
+			item := prompt.HistoryItem{Question: q.Content}
+			if q.Answer != nil {
+				item.Answer = q.Answer.Content
+			}
+			history = append(history, item)
+		}
+		data.History = prompt.FormatHistory(history)
+	}
+	return data
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
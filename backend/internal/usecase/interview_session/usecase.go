@@ -0,0 +1,414 @@
+package interview_session
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/apperror"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/llm"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/repository"
+)
+
+var (
+	// ErrInvalidStatus はセッションのステータスが要求された操作を許可していない場合に返されます
+	ErrInvalidStatus = apperror.Conflict("invalid interview session status")
+	// ErrCompanyRequired は求人IDのみが指定された場合に返されます
+	ErrCompanyRequired = apperror.Validation("company_id is required when job_posting_id is specified",
+		apperror.Detail{Field: "company_id", Message: "is required when job_posting_id is specified"})
+	// ErrCompanyNotFound は指定された企業が存在しない場合に返されます
+	ErrCompanyNotFound = apperror.Validation("company not found",
+		apperror.Detail{Field: "company_id", Message: "company not found"})
+	// ErrJobPostingNotFound は指定された求人が存在しない、または企業に紐づかない場合に返されます
+	ErrJobPostingNotFound = apperror.Validation("job posting not found",
+		apperror.Detail{Field: "job_posting_id", Message: "job posting not found"})
+)
+
+type UseCase interface {
+	CreateSession(ctx context.Context, session *entity.InterviewSession) error
+	GetGreeting(ctx context.Context, id int) (*entity.GreetingResponse, error)
+	SubmitSelfIntroduction(ctx context.Context, id int, currentStatus entity.InterviewSessionStatus, previousAnswer string) (*entity.InterviewProgressResponse, error)
+	SubmitIceBreak(ctx context.Context, id int, currentStatus entity.InterviewSessionStatus, previousAnswer string) (*entity.InterviewProgressResponse, error)
+	SubmitQuestion(ctx context.Context, id int, currentStatus entity.InterviewSessionStatus, previousAnswer string) (*entity.InterviewProgressResponse, error)
+	// StreamQuestion は SubmitQuestion と同様に回答を保存して次の質問を生成し、生成中の発話を onDelta に逐次渡します
+	StreamQuestion(ctx context.Context, id int, currentStatus entity.InterviewSessionStatus, previousAnswer string, onDelta llm.DeltaFunc) (*entity.InterviewProgressResponse, error)
+	// SubmitAnswer は currentStatus の次のフェーズを判定し、回答を保存してそのフェーズの質問を生成します（音声面接用）
+	SubmitAnswer(ctx context.Context, id int, currentStatus entity.InterviewSessionStatus, previousAnswer string, onDelta llm.DeltaFunc) (*entity.InterviewProgressResponse, error)
+	// PauseSession は実施中（GREETING・SELF_INTRODUCTION・ICE_BREAK・MAIN）のセッションを一時中断（PAUSED）します
+	PauseSession(ctx context.Context, id int) (*entity.InterviewProgressResponse, error)
+	// ResumeSession は一時中断したセッションを中断前のフェーズに戻し、未回答の質問を next_question として返します
+	ResumeSession(ctx context.Context, id int) (*entity.InterviewProgressResponse, error)
+	// TerminateSession は実施中または一時中断したセッションを途中終了（TERMINATED）します
+	TerminateSession(ctx context.Context, id int) (*entity.InterviewProgressResponse, error)
+}
+
+type usecase struct {
+	repo           repository.InterviewSessionRepository
+	companyRepo    repository.CompanyRepository
+	jobPostingRepo repository.JobPostingRepository
+	generator      QuestionGenerator
+}
+
+func NewUseCase(repo repository.InterviewSessionRepository, companyRepo repository.CompanyRepository, jobPostingRepo repository.JobPostingRepository, generator QuestionGenerator) UseCase {
+	return &usecase{
+		repo:           repo,
+		companyRepo:    companyRepo,
+		jobPostingRepo: jobPostingRepo,
+		generator:      generator,
+	}
+}
+
+func (u *usecase) CreateSession(ctx context.Context, session *entity.InterviewSession) error {
+	if session.JobPostingID != nil && session.CompanyID == nil {
+		return ErrCompanyRequired
+	}
+
+	if session.CompanyID != nil {
+		if _, err := u.companyRepo.GetCompanyByID(ctx, *session.CompanyID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrCompanyNotFound
+			}
+			return err
+		}
+	}
+
+	if session.JobPostingID != nil {
+		jobPosting, err := u.jobPostingRepo.GetJobPosting(ctx, *session.JobPostingID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrJobPostingNotFound
+			}
+			return err
+		}
+		if jobPosting.CompanyID != *session.CompanyID {
+			return ErrJobPostingNotFound
+		}
+	}
+
+	session.Status = entity.InterviewSessionStatusCreated
+	session.StartedAt = time.Now()
+	session.EndedAt = nil
+
+	return u.repo.CreateSession(ctx, session)
+}
+
+func (u *usecase) GetGreeting(ctx context.Context, id int) (*entity.GreetingResponse, error) {
+	session, err := u.repo.GetSession(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if session.Status != entity.InterviewSessionStatusCreated {
+		return nil, ErrInvalidStatus
+	}
+
+	question, err := u.generateQuestion(ctx, session, entity.InterviewSessionStatusGreeting, 1, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	session.Status = entity.InterviewSessionStatusGreeting
+	if err := u.advance(ctx, session, entity.InterviewSessionStatusCreated, nil, question); err != nil {
+		return nil, err
+	}
+
+	return &entity.GreetingResponse{
+		Question:     toQuestionResponse(question),
+		NextStatus:   statusAfter(session, entity.InterviewSessionStatusGreeting),
+		AudioEnabled: true,
+	}, nil
+}
+
+func (u *usecase) SubmitSelfIntroduction(ctx context.Context, id int, currentStatus entity.InterviewSessionStatus, previousAnswer string) (*entity.InterviewProgressResponse, error) {
+	return u.submitAnswer(ctx, id, currentStatus, previousAnswer, entity.InterviewSessionStatusSelfIntroduction, nil)
+}
+
+func (u *usecase) SubmitIceBreak(ctx context.Context, id int, currentStatus entity.InterviewSessionStatus, previousAnswer string) (*entity.InterviewProgressResponse, error) {
+	return u.submitAnswer(ctx, id, currentStatus, previousAnswer, entity.InterviewSessionStatusIceBreak, nil)
+}
+
+func (u *usecase) SubmitQuestion(ctx context.Context, id int, currentStatus entity.InterviewSessionStatus, previousAnswer string) (*entity.InterviewProgressResponse, error) {
+	return u.submitAnswer(ctx, id, currentStatus, previousAnswer, entity.InterviewSessionStatusMain, nil)
+}
+
+func (u *usecase) StreamQuestion(ctx context.Context, id int, currentStatus entity.InterviewSessionStatus, previousAnswer string, onDelta llm.DeltaFunc) (*entity.InterviewProgressResponse, error) {
+	return u.submitAnswer(ctx, id, currentStatus, previousAnswer, entity.InterviewSessionStatusMain, onDelta)
+}
+
+func (u *usecase) SubmitAnswer(ctx context.Context, id int, currentStatus entity.InterviewSessionStatus, previousAnswer string, onDelta llm.DeltaFunc) (*entity.InterviewProgressResponse, error) {
+	return u.submitAnswer(ctx, id, currentStatus, previousAnswer, "", onDelta)
+}
+
+// submitAnswer は直前の質問への回答を保存し、target のフェーズの質問を生成します。
+// target が空の場合は現在のフェーズの次のフェーズを対象とします。
+// onDelta が指定されている場合は生成中の発話を逐次渡します。
+func (u *usecase) submitAnswer(ctx context.Context, id int, currentStatus entity.InterviewSessionStatus, previousAnswer string, target entity.InterviewSessionStatus, onDelta llm.DeltaFunc) (*entity.InterviewProgressResponse, error) {
+	session, err := u.repo.GetSession(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	from := session.Status
+	if target == "" {
+		target = statusAfter(session, from)
+	}
+	if from != currentStatus || target == "" || statusAfter(session, from) != target {
+		return nil, ErrInvalidStatus
+	}
+
+	last := lastQuestion(session)
+	if last == nil || last.Answer != nil {
+		return nil, ErrInvalidStatus
+	}
+	answer := &entity.InterviewAnswer{QuestionID: last.ID, Content: previousAnswer}
+	last.Answer = answer
+
+	// 設定された質問数に達した場合は面接を終了する
+	if target == entity.InterviewSessionStatusMain && last.Sequence >= session.QuestionCount {
+		endedAt := time.Now()
+		session.Status = entity.InterviewSessionStatusCompleted
+		session.EndedAt = &endedAt
+		if err := u.advance(ctx, session, from, answer, nil); err != nil {
+			return nil, err
+		}
+		return &entity.InterviewProgressResponse{
+			Status:             entity.InterviewSessionStatusCompleted,
+			NextQuestion:       nil,
+			AudioEnabled:       true,
+			RemainingQuestions: 0,
+			ShouldEndSession:   true,
+		}, nil
+	}
+
+	question, err := u.generateQuestion(ctx, session, target, last.Sequence+1, onDelta)
+	if err != nil {
+		return nil, err
+	}
+
+	session.Status = target
+	if err := u.advance(ctx, session, from, answer, question); err != nil {
+		return nil, err
+	}
+
+	next := toQuestionResponse(question)
+	return &entity.InterviewProgressResponse{
+		Status:             target,
+		NextQuestion:       &next,
+		AudioEnabled:       true,
+		RemainingQuestions: remainingQuestions(session, question.Sequence),
+		ShouldEndSession:   false,
+	}, nil
+}
+
+func (u *usecase) PauseSession(ctx context.Context, id int) (*entity.InterviewProgressResponse, error) {
+	session, err := u.repo.GetSession(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	from := session.Status
+	last := lastQuestion(session)
+	if !inProgress(from) || last == nil {
+		return nil, ErrInvalidStatus
+	}
+
+	session.Status = entity.InterviewSessionStatusPaused
+	if err := u.advance(ctx, session, from, nil, nil); err != nil {
+		return nil, err
+	}
+	return &entity.InterviewProgressResponse{
+		Status:             entity.InterviewSessionStatusPaused,
+		NextQuestion:       nil,
+		AudioEnabled:       true,
+		RemainingQuestions: remainingQuestions(session, last.Sequence),
+		ShouldEndSession:   false,
+	}, nil
+}
+
+func (u *usecase) ResumeSession(ctx context.Context, id int) (*entity.InterviewProgressResponse, error) {
+	session, err := u.repo.GetSession(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// 回答は次の質問と同時に保存するため、一時中断中のセッションの最後の質問は未回答
+	last := lastQuestion(session)
+	if session.Status != entity.InterviewSessionStatusPaused || last == nil || last.Answer != nil {
+		return nil, ErrInvalidStatus
+	}
+
+	session.Status = questionStatus(session, last.Sequence)
+	if err := u.advance(ctx, session, entity.InterviewSessionStatusPaused, nil, nil); err != nil {
+		return nil, err
+	}
+	next := toQuestionResponse(last)
+	return &entity.InterviewProgressResponse{
+		Status:             session.Status,
+		NextQuestion:       &next,
+		AudioEnabled:       true,
+		RemainingQuestions: remainingQuestions(session, last.Sequence),
+		ShouldEndSession:   false,
+	}, nil
+}
+
+func (u *usecase) TerminateSession(ctx context.Context, id int) (*entity.InterviewProgressResponse, error) {
+	session, err := u.repo.GetSession(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	from := session.Status
+	if !inProgress(from) && from != entity.InterviewSessionStatusCreated && from != entity.InterviewSessionStatusPaused {
+		return nil, ErrInvalidStatus
+	}
+
+	endedAt := time.Now()
+	session.Status = entity.InterviewSessionStatusTerminated
+	session.EndedAt = &endedAt
+	if err := u.advance(ctx, session, from, nil, nil); err != nil {
+		return nil, err
+	}
+	return &entity.InterviewProgressResponse{
+		Status:             entity.InterviewSessionStatusTerminated,
+		NextQuestion:       nil,
+		AudioEnabled:       true,
+		RemainingQuestions: 0,
+		ShouldEndSession:   true,
+	}, nil
+}
+
+// generateQuestion は企業・求人情報を読み込み、status のフェーズの質問を生成します
+func (u *usecase) generateQuestion(ctx context.Context, session *entity.InterviewSession, status entity.InterviewSessionStatus, sequence int, onDelta llm.DeltaFunc) (*entity.InterviewQuestion, error) {
+	input := GenerateInput{Session: session, Status: status, OnDelta: onDelta}
+
+	if session.CompanyID != nil {
+		company, err := u.companyRepo.GetCompanyByID(ctx, *session.CompanyID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		input.Company = company
+	}
+	if session.JobPostingID != nil {
+		jobPosting, err := u.jobPostingRepo.GetJobPosting(ctx, *session.JobPostingID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		input.JobPosting = jobPosting
+	}
+
+	generated, err := u.generator.Generate(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	question := &entity.InterviewQuestion{Content: generated.Content, Sequence: sequence}
+	if generated.PromptVersion != "" {
+		question.PromptVersion = &generated.PromptVersion
+	}
+	return question, nil
+}
+
+func (u *usecase) advance(ctx context.Context, session *entity.InterviewSession, from entity.InterviewSessionStatus, answer *entity.InterviewAnswer, question *entity.InterviewQuestion) error {
+	if err := u.repo.AdvanceSession(ctx, session, from, answer, question); err != nil {
+		if errors.Is(err, repository.ErrSessionStatusConflict) {
+			return ErrInvalidStatus
+		}
+		return err
+	}
+	return nil
+}
+
+// statusAfter は from のフェーズを終えた後に進むフェーズを返します。
+// 遷移先が存在しない場合は空文字を返します。
+func statusAfter(session *entity.InterviewSession, from entity.InterviewSessionStatus) entity.InterviewSessionStatus {
+	switch from {
+	case entity.InterviewSessionStatusCreated:
+		return entity.InterviewSessionStatusGreeting
+	case entity.InterviewSessionStatusGreeting:
+		if session.IncludeSelfIntroduction {
+			return entity.InterviewSessionStatusSelfIntroduction
+		}
+		if session.IncludeIceBreak {
+			return entity.InterviewSessionStatusIceBreak
+		}
+		return entity.InterviewSessionStatusMain
+	case entity.InterviewSessionStatusSelfIntroduction:
+		if session.IncludeIceBreak {
+			return entity.InterviewSessionStatusIceBreak
+		}
+		return entity.InterviewSessionStatusMain
+	case entity.InterviewSessionStatusIceBreak, entity.InterviewSessionStatusMain:
+		return entity.InterviewSessionStatusMain
+	default:
+		return ""
+	}
+}
+
+// inProgress は status が質問に回答中のフェーズかどうかを返します
+func inProgress(status entity.InterviewSessionStatus) bool {
+	switch status {
+	case entity.InterviewSessionStatusGreeting,
+		entity.InterviewSessionStatusSelfIntroduction,
+		entity.InterviewSessionStatusIceBreak,
+		entity.InterviewSessionStatusMain:
+		return true
+	default:
+		return false
+	}
+}
+
+// questionStatus は sequence 番目の質問を提示したフェーズを返します（一時中断からの再開に使用）
+func questionStatus(session *entity.InterviewSession, sequence int) entity.InterviewSessionStatus {
+	status := entity.InterviewSessionStatusGreeting
+	for i := 1; i < sequence; i++ {
+		status = statusAfter(session, status)
+	}
+	return status
+}
+
+// preMainQuestionCount は主質問より前に行う質問（挨拶・自己紹介・アイスブレイク）の数を返します
+func preMainQuestionCount(session *entity.InterviewSession) int {
+	count := 1
+	if session.IncludeSelfIntroduction {
+		count++
+	}
+	if session.IncludeIceBreak {
+		count++
+	}
+	return count
+}
+
+// remainingQuestions は sequence 番目の質問を提示した時点で残っている主質問の数を返します
+func remainingQuestions(session *entity.InterviewSession, sequence int) int {
+	mainTotal := session.QuestionCount - preMainQuestionCount(session)
+	mainAsked := sequence - preMainQuestionCount(session)
+	if mainAsked < 0 {
+		mainAsked = 0
+	}
+	remaining := mainTotal - mainAsked
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+func lastQuestion(session *entity.InterviewSession) *entity.InterviewQuestion {
+	var last *entity.InterviewQuestion
+	for i := range session.Questions {
+		if last == nil || session.Questions[i].Sequence > last.Sequence {
+			last = &session.Questions[i]
+		}
+	}
+	return last
+}
+
+func toQuestionResponse(question *entity.InterviewQuestion) entity.InterviewQuestionResponse {
+	return entity.InterviewQuestionResponse{
+		ID:       question.ID,
+		Content:  question.Content,
+		Sequence: question.Sequence,
+	}
+}
@@ -0,0 +1,24 @@
+package job
+
+import (
+	"context"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/repository"
+)
+
+type UseCase interface {
+	GetJob(ctx context.Context, id int) (*entity.Job, error)
+}
+
+type usecase struct {
+	repo repository.JobRepository
+}
+
+func NewUseCase(repo repository.JobRepository) UseCase {
+	return &usecase{repo: repo}
+}
+
+func (u *usecase) GetJob(ctx context.Context, id int) (*entity.Job, error) {
+	return u.repo.GetJob(ctx, id)
+}
@@ -3,32 +3,117 @@ package job_posting
 import (
 	"context"
 
+	"github.com/takanoakira/ai-interview-practice/backend/internal/apperror"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/auth"
 	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
 	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/repository"
 )
 
+var (
+	// ErrCompanyNotFound は company_id に指定された企業が存在しない、または呼び出し元のワークスペースに属さない場合に返されます
+	ErrCompanyNotFound = apperror.Unprocessable("company not found",
+		apperror.Detail{Field: "company_id", Message: "must be an existing company"})
+	// ErrCompanyChanged は更新で company_id を変更しようとした場合に返されます。企業間の移動は MoveJobPosting で行います。
+	ErrCompanyChanged = apperror.Unprocessable("company_id cannot be changed by update",
+		apperror.Detail{Field: "company_id", Message: "use POST /api/v1/job-postings/:id/move to move the job posting to another company"})
+)
+
 type UseCase interface {
+	GetJobPosting(ctx context.Context, id int) (*entity.JobPosting, error)
+	ListJobPostingsByCompany(ctx context.Context, companyID, page, limit int) (*entity.JobPostingResponse, error)
 	CreateJobPosting(ctx context.Context, jobPosting *entity.JobPosting) (*entity.JobPosting, error)
+	// UpdateJobPosting は求人情報を更新します。company_id が現在の企業と異なる場合は ErrCompanyChanged を返します。
+	// jobPosting.Version が 0 でなければ現在のバージョンと一致する場合のみ更新します。
 	UpdateJobPosting(ctx context.Context, jobPosting *entity.JobPosting) (*entity.JobPosting, error)
-	DeleteJobPosting(ctx context.Context, id int) error
+	// MoveJobPosting は求人情報を別の企業に移動し、移動履歴を記録します
+	MoveJobPosting(ctx context.Context, id, companyID int, reason *string) (*entity.JobPosting, error)
+	ListJobPostingMoves(ctx context.Context, id int) ([]entity.JobPostingMove, error)
+	// DeleteJobPosting は求人情報を削除します。version が 0 でなければ現在のバージョンと一致する場合のみ削除します。
+	DeleteJobPosting(ctx context.Context, id, version int) error
+	// RestoreJobPosting はゴミ箱にある求人情報を元に戻します。企業がゴミ箱にある場合は企業を先に戻す必要があります。
+	RestoreJobPosting(ctx context.Context, id int) (*entity.JobPosting, error)
 }
 
 type usecase struct {
-	repo repository.JobPostingRepository
+	repo        repository.JobPostingRepository
+	companyRepo repository.CompanyRepository
+}
+
+func NewUseCase(repo repository.JobPostingRepository, companyRepo repository.CompanyRepository) UseCase {
+	return &usecase{repo: repo, companyRepo: companyRepo}
 }
 
-func NewUseCase(repo repository.JobPostingRepository) UseCase {
-	return &usecase{repo: repo}
+func (u *usecase) GetJobPosting(ctx context.Context, id int) (*entity.JobPosting, error) {
+	return u.repo.GetJobPosting(ctx, id)
+}
+
+func (u *usecase) ListJobPostingsByCompany(ctx context.Context, companyID, page, limit int) (*entity.JobPostingResponse, error) {
+	return u.repo.ListJobPostingsByCompany(ctx, companyID, page, limit)
 }
 
 func (u *usecase) CreateJobPosting(ctx context.Context, jobPosting *entity.JobPosting) (*entity.JobPosting, error) {
+	if err := u.ensureCompanyExists(ctx, jobPosting.CompanyID); err != nil {
+		return nil, err
+	}
 	return u.repo.CreateJobPosting(ctx, jobPosting)
 }
 
 func (u *usecase) UpdateJobPosting(ctx context.Context, jobPosting *entity.JobPosting) (*entity.JobPosting, error) {
+	current, err := u.repo.GetJobPosting(ctx, jobPosting.ID)
+	if err != nil {
+		return nil, err
+	}
+	if jobPosting.CompanyID != current.CompanyID {
+		return nil, ErrCompanyChanged
+	}
 	return u.repo.UpdateJobPosting(ctx, jobPosting)
 }
 
-func (u *usecase) DeleteJobPosting(ctx context.Context, id int) error {
-	return u.repo.DeleteJobPosting(ctx, id)
+func (u *usecase) MoveJobPosting(ctx context.Context, id, companyID int, reason *string) (*entity.JobPosting, error) {
+	current, err := u.repo.GetJobPosting(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.ensureCompanyExists(ctx, companyID); err != nil {
+		return nil, err
+	}
+	// 移動先が現在の企業と同じ場合は履歴を残さない
+	if current.CompanyID == companyID {
+		return current, nil
+	}
+
+	userID, err := auth.UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return u.repo.MoveJobPosting(ctx, &entity.JobPostingMove{
+		JobPostingID:  id,
+		ToCompanyID:   companyID,
+		MovedByUserID: &userID,
+		Reason:        reason,
+	})
+}
+
+func (u *usecase) ListJobPostingMoves(ctx context.Context, id int) ([]entity.JobPostingMove, error) {
+	return u.repo.ListJobPostingMoves(ctx, id)
+}
+
+func (u *usecase) DeleteJobPosting(ctx context.Context, id, version int) error {
+	return u.repo.DeleteJobPosting(ctx, id, version)
+}
+
+// ensureCompanyExists は企業が存在し、呼び出し元のワークスペースに属することを確認します
+func (u *usecase) ensureCompanyExists(ctx context.Context, companyID int) error {
+	exists, err := u.companyRepo.CompanyExists(ctx, companyID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrCompanyNotFound
+	}
+	return nil
+}
+
+func (u *usecase) RestoreJobPosting(ctx context.Context, id int) (*entity.JobPosting, error) {
+	return u.repo.RestoreJobPosting(ctx, id)
 }
@@ -0,0 +1,92 @@
+package job_posting_parse
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/llm"
+)
+
+// extractionSchema は求人票の項目抽出の出力を制約する JSON スキーマです
+var extractionSchema = &llm.JSONSchema{
+	Name:   "job_posting_extraction",
+	Strict: true,
+	Schema: json.RawMessage(`{
+  "type": "object",
+  "properties": {
+    "title": {"type": "string"},
+    "employment_type": {"type": "string"},
+    "salary_min": {"type": ["integer", "null"]},
+    "salary_max": {"type": ["integer", "null"]},
+    "salary_currency": {"type": "string"},
+    "salary_unit": {"type": "string", "enum": ["year", "month", "day", "hour", ""]},
+    "salary_text": {"type": "string"},
+    "location": {"type": "string"},
+    "required_skills": {"type": "array", "items": {"type": "string"}},
+    "benefits": {"type": "array", "items": {"type": "string"}}
+  },
+  "required": ["title", "employment_type", "salary_min", "salary_max", "salary_currency", "salary_unit", "salary_text", "location", "required_skills", "benefits"],
+  "additionalProperties": false
+}`),
+}
+
+// extractionOutput は求人票の項目抽出の LLM 出力です
+type extractionOutput struct {
+	Title          string            `json:"title"`
+	EmploymentType string            `json:"employment_type"`
+	SalaryMin      *int              `json:"salary_min"`
+	SalaryMax      *int              `json:"salary_max"`
+	SalaryCurrency string            `json:"salary_currency"`
+	SalaryUnit     entity.SalaryUnit `json:"salary_unit"`
+	SalaryText     string            `json:"salary_text"`
+	Location       string            `json:"location"`
+	RequiredSkills []string          `json:"required_skills"`
+	Benefits       []string          `json:"benefits"`
+}
+
+// parseExtraction は LLM の出力を検証し、抽出した項目に変換します
+func parseExtraction(content string) (*entity.ExtractedJobPosting, error) {
+	var out extractionOutput
+	dec := json.NewDecoder(bytes.NewReader([]byte(strings.TrimSpace(content))))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&out); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidExtraction, err)
+	}
+	switch out.SalaryUnit {
+	case "", entity.SalaryUnitYear, entity.SalaryUnitMonth, entity.SalaryUnitDay, entity.SalaryUnitHour:
+	default:
+		return nil, fmt.Errorf("%w: unknown salary_unit %q", ErrInvalidExtraction, out.SalaryUnit)
+	}
+
+	extracted := &entity.ExtractedJobPosting{
+		Title:          strings.TrimSpace(out.Title),
+		EmploymentType: strings.TrimSpace(out.EmploymentType),
+		Location:       strings.TrimSpace(out.Location),
+		RequiredSkills: nonEmpty(out.RequiredSkills),
+		Benefits:       nonEmpty(out.Benefits),
+	}
+	if out.SalaryMin != nil || out.SalaryMax != nil || strings.TrimSpace(out.SalaryText) != "" {
+		extracted.Salary = &entity.SalaryRange{
+			Min:      out.SalaryMin,
+			Max:      out.SalaryMax,
+			Currency: strings.TrimSpace(out.SalaryCurrency),
+			Unit:     out.SalaryUnit,
+			Text:     strings.TrimSpace(out.SalaryText),
+		}
+	}
+	return extracted, nil
+}
+
+// nonEmpty は空白のみの項目を取り除きます
+func nonEmpty(items []string) []string {
+	var result []string
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
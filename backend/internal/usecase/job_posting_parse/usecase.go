@@ -0,0 +1,168 @@
+package job_posting_parse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/apperror"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/llm"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/htmldoc"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/jobparse"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/prompt"
+)
+
+const (
+	// MaxTextLength は解析できる求人票のテキストの最大文字数です
+	MaxTextLength = 50000
+	// maxPromptTextLength は LLM に渡す求人票のテキストの最大文字数です
+	maxPromptTextLength = 8000
+)
+
+var (
+	// ErrEmptyText は求人票のテキストが空の場合に返されます
+	ErrEmptyText = apperror.Validation("job posting text is empty", apperror.Detail{Field: "text", Message: "must not be empty"})
+	// ErrTextTooLong は求人票のテキストが MaxTextLength を超える場合に返されます
+	ErrTextTooLong = apperror.Validation("job posting text is too long",
+		apperror.Detail{Field: "text", Message: fmt.Sprintf("must be at most %d characters", MaxTextLength)})
+	// ErrInvalidExtraction は LLM の出力が期待するスキーマに従っていない場合に返されます
+	ErrInvalidExtraction = apperror.UpstreamError("invalid job posting extraction output")
+)
+
+// Input は求人票の解析の入力です
+type Input struct {
+	// Text は求人票のテキストです（HTML はテキストに変換済み）
+	Text string
+	// TitleHint は HTML の見出しなど、タイトルの候補です
+	TitleHint string
+	// UseLLM は規則で抽出できなかった項目を LLM で補う場合に true です
+	UseLLM bool
+}
+
+type UseCase interface {
+	// Parse は求人票のテキストから求人の項目を抽出します。
+	// LLM を利用できない・失敗した場合はエラーにせず、規則による抽出結果に注意事項を付けて返します。
+	Parse(ctx context.Context, input Input) (*entity.JobPostingExtraction, error)
+}
+
+type usecase struct {
+	provider llm.Provider
+	prompts  *prompt.Registry
+}
+
+// NewUseCase は求人票の解析のユースケースを生成します。provider が nil の場合は LLM による補完を行いません。
+func NewUseCase(provider llm.Provider, prompts *prompt.Registry) UseCase {
+	return &usecase{provider: provider, prompts: prompts}
+}
+
+func (u *usecase) Parse(ctx context.Context, input Input) (*entity.JobPostingExtraction, error) {
+	text := htmldoc.NormalizeText(input.Text)
+	if text == "" {
+		return nil, ErrEmptyText
+	}
+	if utf8.RuneCountInString(text) > MaxTextLength {
+		return nil, ErrTextTooLong
+	}
+
+	result := &entity.JobPostingExtraction{
+		Extracted: jobparse.Extract(text, input.TitleHint),
+		Warnings:  []string{},
+	}
+	if input.UseLLM {
+		if err := u.assist(ctx, text, result); err != nil {
+			return nil, err
+		}
+	}
+	for _, name := range missingFields(&result.Extracted) {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("%s could not be extracted", name))
+	}
+	return result, nil
+}
+
+// assist は LLM で抽出した項目で、規則で抽出できなかった項目を補います。
+// LLM の失敗は注意事項として記録し、リクエストのキャンセル・テンプレートの不備のみをエラーとして返します。
+func (u *usecase) assist(ctx context.Context, text string, result *entity.JobPostingExtraction) error {
+	if u.provider == nil {
+		result.Warnings = append(result.Warnings, "LLM assistance is not configured")
+		return nil
+	}
+
+	rendered, err := u.prompts.Render(prompt.JobPostingExtraction, prompt.JobPostingExtractionData{
+		Text: htmldoc.Truncate(text, maxPromptTextLength),
+	})
+	if err != nil {
+		return err
+	}
+	resp, err := u.provider.Complete(ctx, llm.CompletionRequest{
+		Messages:   []llm.Message{llm.UserMessage(rendered)},
+		JSONSchema: extractionSchema,
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			return err
+		}
+		if errors.Is(err, llm.ErrUnavailable) {
+			result.Warnings = append(result.Warnings, "LLM assistance is temporarily unavailable")
+		} else {
+			result.Warnings = append(result.Warnings, "LLM assistance failed")
+		}
+		return nil
+	}
+	extracted, err := parseExtraction(resp.Content)
+	if err != nil {
+		result.Warnings = append(result.Warnings, "LLM assistance returned an invalid result")
+		return nil
+	}
+	result.LLMAssisted = fillMissing(&result.Extracted, extracted)
+	return nil
+}
+
+// fillMissing は dst の空の項目を src の値で補います。補った項目があれば true を返します。
+func fillMissing(dst, src *entity.ExtractedJobPosting) bool {
+	filled := false
+	fill := func(dst *string, src string) {
+		if *dst == "" && src != "" {
+			*dst, filled = src, true
+		}
+	}
+	fill(&dst.Title, htmldoc.Truncate(src.Title, 100))
+	fill(&dst.EmploymentType, src.EmploymentType)
+	fill(&dst.Location, src.Location)
+	// 規則で金額を読み取れなかった給与（表記のみ）は LLM の結果で置き換える
+	if src.Salary != nil && (dst.Salary == nil || (dst.Salary.Min == nil && dst.Salary.Max == nil && (src.Salary.Min != nil || src.Salary.Max != nil))) {
+		dst.Salary, filled = src.Salary, true
+	}
+	if len(dst.RequiredSkills) == 0 && len(src.RequiredSkills) > 0 {
+		dst.RequiredSkills, filled = src.RequiredSkills, true
+	}
+	if len(dst.Benefits) == 0 && len(src.Benefits) > 0 {
+		dst.Benefits, filled = src.Benefits, true
+	}
+	return filled
+}
+
+// missingFields は抽出できなかった項目の名前を返します
+func missingFields(e *entity.ExtractedJobPosting) []string {
+	var missing []string
+	if e.Title == "" {
+		missing = append(missing, "title")
+	}
+	if e.EmploymentType == "" {
+		missing = append(missing, "employment_type")
+	}
+	if e.Salary == nil {
+		missing = append(missing, "salary")
+	}
+	if e.Location == "" {
+		missing = append(missing, "location")
+	}
+	if len(e.RequiredSkills) == 0 {
+		missing = append(missing, "required_skills")
+	}
+	if len(e.Benefits) == 0 {
+		missing = append(missing, "benefits")
+	}
+	return missing
+}
@@ -0,0 +1,137 @@
+package organization
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/apperror"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/auth"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/repository"
+)
+
+// invitationTTL は招待の有効期間です
+const invitationTTL = 7 * 24 * time.Hour
+
+// ErrForbidden はユーザーのロールでは操作が許可されていない場合に返されます
+var ErrForbidden = apperror.Forbidden("operation is not permitted for your role")
+
+type UseCase interface {
+	// CreateOrganization は組織を作成し、呼び出し元のユーザーを owner として所属させます
+	CreateOrganization(ctx context.Context, name string) (*entity.Membership, error)
+	// ListOrganizations は呼び出し元のユーザーが所属する組織をロールとともに返します
+	ListOrganizations(ctx context.Context) ([]entity.Membership, error)
+	// GetMembership は呼び出し元のユーザーの組織への所属を返します。所属していない場合は gorm.ErrRecordNotFound を返します。
+	GetMembership(ctx context.Context, organizationID int) (*entity.Membership, error)
+	// ListMembers は組織のメンバーを返します（candidate は参照できません）
+	ListMembers(ctx context.Context, organizationID int) ([]entity.Membership, error)
+	// CreateInvitation は招待を作成し、招待トークンを返します（owner・admin のみ）。
+	// トークンはハッシュ値のみを保存するため、作成時にのみ取得できます。
+	CreateInvitation(ctx context.Context, organizationID int, email *string, role entity.MembershipRole) (*entity.InvitationResponse, error)
+	// AcceptInvitation は招待トークンを使用して呼び出し元のユーザーを組織に所属させます
+	AcceptInvitation(ctx context.Context, token string) (*entity.Membership, error)
+}
+
+type usecase struct {
+	repo repository.OrganizationRepository
+	now  func() time.Time
+}
+
+func NewUseCase(repo repository.OrganizationRepository) UseCase {
+	return &usecase{repo: repo, now: time.Now}
+}
+
+func (u *usecase) CreateOrganization(ctx context.Context, name string) (*entity.Membership, error) {
+	userID, err := auth.UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return u.repo.CreateOrganization(ctx, &entity.Organization{Name: name}, userID)
+}
+
+func (u *usecase) ListOrganizations(ctx context.Context) ([]entity.Membership, error) {
+	userID, err := auth.UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return u.repo.ListMembershipsByUser(ctx, userID)
+}
+
+func (u *usecase) GetMembership(ctx context.Context, organizationID int) (*entity.Membership, error) {
+	userID, err := auth.UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return u.repo.GetMembership(ctx, organizationID, userID)
+}
+
+func (u *usecase) ListMembers(ctx context.Context, organizationID int) ([]entity.Membership, error) {
+	membership, err := u.GetMembership(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	if membership.Role == entity.MembershipRoleCandidate {
+		return nil, ErrForbidden
+	}
+	return u.repo.ListMembers(ctx, organizationID)
+}
+
+func (u *usecase) CreateInvitation(ctx context.Context, organizationID int, email *string, role entity.MembershipRole) (*entity.InvitationResponse, error) {
+	membership, err := u.GetMembership(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	// admin は owner のみが招待できる
+	switch membership.Role {
+	case entity.MembershipRoleOwner:
+	case entity.MembershipRoleAdmin:
+		if role == entity.MembershipRoleAdmin {
+			return nil, ErrForbidden
+		}
+	default:
+		return nil, ErrForbidden
+	}
+
+	token, err := newInvitationToken()
+	if err != nil {
+		return nil, err
+	}
+	invitation := entity.OrganizationInvitation{
+		OrganizationID:  organizationID,
+		TokenHash:       hashToken(token),
+		Email:           email,
+		Role:            role,
+		InvitedByUserID: membership.UserID,
+		ExpiresAt:       u.now().Add(invitationTTL),
+	}
+	if err := u.repo.CreateInvitation(ctx, &invitation); err != nil {
+		return nil, err
+	}
+	return &entity.InvitationResponse{Invitation: invitation, Token: token}, nil
+}
+
+func (u *usecase) AcceptInvitation(ctx context.Context, token string) (*entity.Membership, error) {
+	userID, err := auth.UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return u.repo.AcceptInvitation(ctx, hashToken(token), userID, u.now())
+}
+
+// newInvitationToken は推測できない招待トークンを生成します
+func newInvitationToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,75 @@
+package speech
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gabriel-vasile/mimetype"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/speech"
+)
+
+type UseCase interface {
+	// Transcribe は音声を検証してテキストに変換します。音声データは保存しません（設計書 3.3 参照）。
+	Transcribe(ctx context.Context, audio speech.Audio) (*speech.Transcript, error)
+	Synthesize(ctx context.Context, req speech.SynthesisRequest) (*speech.SynthesizedAudio, error)
+}
+
+type usecase struct {
+	stt speech.SpeechToText
+	tts speech.TextToSpeech
+}
+
+// NewUseCase は音声認識・音声合成のユースケースを生成します。stt・tts が nil の場合は speech.ErrUnavailable を返します。
+func NewUseCase(stt speech.SpeechToText, tts speech.TextToSpeech) UseCase {
+	return &usecase{stt: stt, tts: tts}
+}
+
+// supportedFormats は音声認識で受け付けるコンテナ形式と、プロバイダーに渡す MIME タイプです
+var supportedFormats = []struct {
+	detected string
+	mimeType string
+}{
+	{"audio/wav", "audio/wav"},
+	{"audio/webm", "audio/webm"},
+	{"audio/ogg", "audio/ogg"},
+	{"application/ogg", "audio/ogg"},
+}
+
+func (u *usecase) Transcribe(ctx context.Context, audio speech.Audio) (*speech.Transcript, error) {
+	if len(audio.Data) == 0 {
+		return nil, speech.ErrEmptyAudio
+	}
+	if len(audio.Data) > speech.MaxAudioBytes {
+		return nil, speech.ErrAudioTooLarge
+	}
+
+	// クライアントの申告ではなく、データの内容からコンテナ形式を判定する
+	mimeType, err := detectFormat(audio.Data)
+	if err != nil {
+		return nil, err
+	}
+	audio.MimeType = mimeType
+
+	if u.stt == nil {
+		return nil, fmt.Errorf("%w: speech-to-text is not configured", speech.ErrUnavailable)
+	}
+	return u.stt.Transcribe(ctx, audio)
+}
+
+func (u *usecase) Synthesize(ctx context.Context, req speech.SynthesisRequest) (*speech.SynthesizedAudio, error) {
+	if u.tts == nil {
+		return nil, fmt.Errorf("%w: text-to-speech is not configured", speech.ErrUnavailable)
+	}
+	return u.tts.Synthesize(ctx, req)
+}
+
+func detectFormat(data []byte) (string, error) {
+	detected := mimetype.Detect(data)
+	for _, f := range supportedFormats {
+		if detected.Is(f.detected) {
+			return f.mimeType, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s", speech.ErrUnsupportedFormat, detected.String())
+}
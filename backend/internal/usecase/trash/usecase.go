@@ -0,0 +1,71 @@
+package trash
+
+import (
+	"context"
+	"time"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/repository"
+)
+
+// DefaultRetention はゴミ箱の項目を完全に削除するまでの既定の保持期間です
+const DefaultRetention = 30 * 24 * time.Hour
+
+type UseCase interface {
+	// ListTrash は呼び出し元のワークスペースのゴミ箱にある企業・求人情報を返します
+	ListTrash(ctx context.Context) (*entity.TrashResponse, error)
+	// PurgeExpired は保持期間を過ぎた企業・求人情報を全てのワークスペースから完全に削除し、件数を返します
+	PurgeExpired(ctx context.Context) (companies, jobPostings int64, err error)
+}
+
+type usecase struct {
+	companyRepo    repository.CompanyRepository
+	jobPostingRepo repository.JobPostingRepository
+	retention      time.Duration
+}
+
+func NewUseCase(companyRepo repository.CompanyRepository, jobPostingRepo repository.JobPostingRepository, retention time.Duration) UseCase {
+	return &usecase{companyRepo: companyRepo, jobPostingRepo: jobPostingRepo, retention: retention}
+}
+
+func (u *usecase) ListTrash(ctx context.Context) (*entity.TrashResponse, error) {
+	companies, err := u.companyRepo.ListDeletedCompanies(ctx)
+	if err != nil {
+		return nil, err
+	}
+	jobPostings, err := u.jobPostingRepo.ListDeletedJobPostings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &entity.TrashResponse{
+		Companies:   make([]entity.TrashedCompany, 0, len(companies)),
+		JobPostings: make([]entity.TrashedJobPosting, 0, len(jobPostings)),
+	}
+	for _, company := range companies {
+		response.Companies = append(response.Companies, entity.TrashedCompany{
+			Company: company,
+			PurgeAt: company.DeletedAt.Time.Add(u.retention),
+		})
+	}
+	for _, jobPosting := range jobPostings {
+		response.JobPostings = append(response.JobPostings, entity.TrashedJobPosting{
+			JobPosting: jobPosting,
+			PurgeAt:    jobPosting.DeletedAt.Time.Add(u.retention),
+		})
+	}
+	return response, nil
+}
+
+func (u *usecase) PurgeExpired(ctx context.Context) (int64, int64, error) {
+	before := time.Now().Add(-u.retention)
+	jobPostings, err := u.jobPostingRepo.PurgeDeletedJobPostings(ctx, before)
+	if err != nil {
+		return 0, 0, err
+	}
+	companies, err := u.companyRepo.PurgeDeletedCompanies(ctx, before)
+	if err != nil {
+		return 0, jobPostings, err
+	}
+	return companies, jobPostings, nil
+}
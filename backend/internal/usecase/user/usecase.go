@@ -0,0 +1,52 @@
+package user
+
+import (
+	"context"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/auth"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/repository"
+)
+
+type UseCase interface {
+	// Authenticate は検証済みのクレームに対応するユーザーを返します。初回のログイン時はユーザーを作成します。
+	Authenticate(ctx context.Context, claims *auth.Claims) (*entity.User, error)
+	// GetCurrentUser は ctx に格納された認証済みのユーザーを返します
+	GetCurrentUser(ctx context.Context) (*entity.User, error)
+}
+
+type usecase struct {
+	repo repository.UserRepository
+}
+
+func NewUseCase(repo repository.UserRepository) UseCase {
+	return &usecase{repo: repo}
+}
+
+func (u *usecase) Authenticate(ctx context.Context, claims *auth.Claims) (*entity.User, error) {
+	user := &entity.User{
+		AuthSubject:   claims.Subject,
+		Email:         optionalString(claims.Email),
+		EmailVerified: claims.Email != "" && claims.EmailVerified,
+		Name:          optionalString(claims.Name),
+	}
+	if err := u.repo.UpsertUserBySubject(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (u *usecase) GetCurrentUser(ctx context.Context) (*entity.User, error) {
+	id, err := auth.UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return u.repo.GetUser(ctx, id)
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
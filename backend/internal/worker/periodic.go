@@ -0,0 +1,55 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// Periodic は関数を一定間隔で実行します（ゴミ箱の完全削除など、ジョブとして登録しない定期処理に使用）。
+// 複数のサーバーで実行しても問題のない冪等な処理に使用してください。
+type Periodic struct {
+	name     string
+	interval time.Duration
+	fn       func(ctx context.Context) error
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+}
+
+func NewPeriodic(name string, interval time.Duration, fn func(ctx context.Context) error) *Periodic {
+	return &Periodic{name: name, interval: interval, fn: fn}
+}
+
+// Start は起動直後に1回実行し、以降は interval ごとに実行します
+func (p *Periodic) Start(ctx context.Context) {
+	ctx, p.cancel = context.WithCancel(ctx)
+	p.wg.Add(1)
+	go p.run(ctx)
+}
+
+// Stop は定期実行を停止し、実行中の処理の終了を待ちます
+func (p *Periodic) Stop() {
+	if p.cancel != nil {
+		p.cancel()
+	}
+	p.wg.Wait()
+}
+
+func (p *Periodic) run(ctx context.Context) {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := p.fn(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("worker: %s failed: %v", p.name, err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,315 @@
+package worker
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/entity"
+	"github.com/takanoakira/ai-interview-practice/backend/internal/domain/repository"
+)
+
+// HandlerFunc はジョブを処理する関数です。戻り値の result は JSON としてジョブに保存されます。
+type HandlerFunc func(ctx context.Context, job *entity.Job) (result interface{}, err error)
+
+// Config はジョブキューの設定です
+type Config struct {
+	// Workers は並行して実行するワーカー数です
+	Workers int
+	// PollInterval は実行可能なジョブを確認する間隔です
+	PollInterval time.Duration
+	// DefaultTimeout は1回の実行のタイムアウトです（Enqueue で timeout を指定しない場合に使用）
+	DefaultTimeout time.Duration
+	// MaxRetries は失敗時の自動リトライ回数です
+	MaxRetries int
+	// BaseBackoff は1回目のリトライまでの待機時間です。以降は2倍ずつ増加します。
+	BaseBackoff time.Duration
+	// MaxBackoff はリトライ待機時間の上限です
+	MaxBackoff time.Duration
+	// LeaseDuration は実行中のジョブのリース期間です。実行中は LeaseDuration / 3 ごとに延長し、
+	// 期限を過ぎたジョブ（停止したワーカーが実行していたもの）は LeaseDuration ごとに再投入します。
+	LeaseDuration time.Duration
+}
+
+// DefaultConfig は既定の設定を返します（設計書 6.1: 最大3回まで自動リトライ、30秒でタイムアウト）
+func DefaultConfig() Config {
+	return Config{
+		Workers:        2,
+		PollInterval:   time.Second,
+		DefaultTimeout: 30 * time.Second,
+		MaxRetries:     3,
+		BaseBackoff:    2 * time.Second,
+		MaxBackoff:     time.Minute,
+		LeaseDuration:  time.Minute,
+	}
+}
+
+// Queue は jobs テーブルに永続化されたジョブをゴルーチンのワーカープールで処理します。
+// 複数のサーバーで同じ jobs テーブルを共有できます。
+type Queue struct {
+	repo     repository.JobRepository
+	config   Config
+	id       string // リースの所有者としてジョブに記録する識別子
+	handlers map[string]HandlerFunc
+	notify   chan struct{}
+	requeuer *Periodic
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+}
+
+func NewQueue(repo repository.JobRepository, config Config) *Queue {
+	return &Queue{
+		repo:     repo,
+		config:   config,
+		id:       newWorkerID(),
+		handlers: map[string]HandlerFunc{},
+		notify:   make(chan struct{}, 1),
+	}
+}
+
+// newWorkerID はホスト名とプロセス ID に乱数を付けた識別子を返します（同じホストでの再起動時も重複しない）
+func newWorkerID() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	if len(host) > 64 {
+		host = host[:64]
+	}
+	suffix := make([]byte, 4)
+	_, _ = rand.Read(suffix)
+	return fmt.Sprintf("%s-%d-%s", host, os.Getpid(), hex.EncodeToString(suffix))
+}
+
+// Register は jobType のジョブを処理する関数を登録します。Start より前に呼び出してください。
+func (q *Queue) Register(jobType string, handler HandlerFunc) {
+	q.handlers[jobType] = handler
+}
+
+// Enqueue はジョブを登録します。timeout は1回の実行のタイムアウトで、0以下の場合は DefaultTimeout を使用します。
+// ジョブはリクエストの終了後に実行されるため、ctx の期限はタイムアウトに影響しません。
+func (q *Queue) Enqueue(ctx context.Context, jobType string, payload interface{}, timeout time.Duration) (*entity.Job, error) {
+	if _, ok := q.handlers[jobType]; !ok {
+		return nil, fmt.Errorf("worker: no handler registered for job type %q", jobType)
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	if timeout <= 0 {
+		timeout = q.config.DefaultTimeout
+	}
+
+	job := &entity.Job{
+		Type:           jobType,
+		Payload:        data,
+		Status:         entity.JobStatusPending,
+		MaxAttempts:    q.config.MaxRetries + 1,
+		TimeoutSeconds: int((timeout + time.Second - 1) / time.Second),
+		RunAt:          time.Now(),
+	}
+	if err := q.repo.CreateJob(ctx, job); err != nil {
+		return nil, err
+	}
+
+	// 待機中のワーカーを起こす
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+
+	return job, nil
+}
+
+// Start はワーカーを起動します。リース期限を過ぎたジョブ（停止したワーカーが実行していたもの）は定期的に再投入します。
+func (q *Queue) Start(ctx context.Context) error {
+	q.requeuer = NewPeriodic("requeue expired jobs", q.config.LeaseDuration, q.requeueExpired)
+	q.requeuer.Start(ctx)
+
+	ctx, q.cancel = context.WithCancel(ctx)
+	for i := 0; i < q.config.Workers; i++ {
+		q.wg.Add(1)
+		go q.run(ctx)
+	}
+	return nil
+}
+
+// Stop はワーカーを停止し、実行中のジョブの終了を待ちます
+func (q *Queue) Stop() {
+	if q.cancel != nil {
+		q.cancel()
+	}
+	q.wg.Wait()
+	if q.requeuer != nil {
+		q.requeuer.Stop()
+	}
+}
+
+func (q *Queue) requeueExpired(ctx context.Context) error {
+	requeued, err := q.repo.RequeueExpiredJobs(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+	if requeued > 0 {
+		log.Printf("worker: requeued %d interrupted job(s)", requeued)
+		select {
+		case q.notify <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+func (q *Queue) run(ctx context.Context) {
+	defer q.wg.Done()
+
+	ticker := time.NewTicker(q.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		// 実行可能なジョブがなくなるまで続けて処理する
+		for ctx.Err() == nil {
+			now := time.Now()
+			job, err := q.repo.ClaimNextJob(ctx, q.id, now, now.Add(q.config.LeaseDuration))
+			if err != nil {
+				if !errors.Is(err, gorm.ErrRecordNotFound) && ctx.Err() == nil {
+					log.Printf("worker: failed to claim job: %v", err)
+				}
+				break
+			}
+			q.process(ctx, job)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-q.notify:
+		}
+	}
+}
+
+func (q *Queue) process(ctx context.Context, job *entity.Job) {
+	ctx, cancel := context.WithCancel(ctx)
+	heartbeat := make(chan struct{})
+	go func() {
+		defer close(heartbeat)
+		q.heartbeat(ctx, job, cancel)
+	}()
+	result, err := q.execute(ctx, job)
+	cancel()
+	<-heartbeat
+
+	// ワーカー停止時もジョブの状態は確実に保存する
+	saveCtx := context.Background()
+
+	if err == nil {
+		data, marshalErr := json.Marshal(result)
+		if marshalErr != nil {
+			err = Permanent(marshalErr)
+		} else if saveErr := q.repo.MarkSucceeded(saveCtx, job.ID, q.id, data); saveErr != nil {
+			log.Printf("worker: failed to mark job %d as succeeded: %v", job.ID, saveErr)
+		}
+		if err == nil {
+			return
+		}
+	}
+
+	var permanent *permanentError
+	if errors.As(err, &permanent) || job.Attempts >= job.MaxAttempts {
+		if saveErr := q.repo.MarkFailed(saveCtx, job.ID, q.id, err.Error()); saveErr != nil {
+			log.Printf("worker: failed to mark job %d as failed: %v", job.ID, saveErr)
+		}
+		return
+	}
+
+	runAt := time.Now().Add(q.backoff(job.Attempts))
+	if saveErr := q.repo.Reschedule(saveCtx, job.ID, q.id, err.Error(), runAt); saveErr != nil {
+		log.Printf("worker: failed to reschedule job %d: %v", job.ID, saveErr)
+	}
+}
+
+// heartbeat は ctx が終了するまでジョブのリースを延長します。
+// リースを失った場合（期限切れで他のワーカーに再投入された場合）は cancel で実行を中断します。
+func (q *Queue) heartbeat(ctx context.Context, job *entity.Job, cancel context.CancelFunc) {
+	ticker := time.NewTicker(q.config.LeaseDuration / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		err := q.repo.ExtendLease(ctx, job.ID, q.id, time.Now().Add(q.config.LeaseDuration))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Printf("worker: lost lease on job %d", job.ID)
+			cancel()
+			return
+		}
+		if err != nil && ctx.Err() == nil {
+			// 一時的なエラーは次の延長で回復できるため、実行は継続する
+			log.Printf("worker: failed to extend lease on job %d: %v", job.ID, err)
+		}
+	}
+}
+
+func (q *Queue) execute(ctx context.Context, job *entity.Job) (result interface{}, err error) {
+	handler, ok := q.handlers[job.Type]
+	if !ok {
+		return nil, Permanent(fmt.Errorf("worker: no handler registered for job type %q", job.Type))
+	}
+
+	timeout := time.Duration(job.TimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = q.config.DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = Permanent(fmt.Errorf("worker: job panicked: %v", r))
+		}
+	}()
+
+	return handler(ctx, job)
+}
+
+// backoff は attempts 回目の失敗後に再実行するまでの待機時間を返します
+func (q *Queue) backoff(attempts int) time.Duration {
+	d := q.config.BaseBackoff
+	for i := 1; i < attempts; i++ {
+		d *= 2
+		if d >= q.config.MaxBackoff {
+			return q.config.MaxBackoff
+		}
+	}
+	return d
+}
+
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent は err をリトライしても解決しないエラーとしてマークします
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
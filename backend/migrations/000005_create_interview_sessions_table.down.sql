@@ -0,0 +1 @@
+DROP TABLE IF EXISTS interview_sessions;
\ No newline at end of file
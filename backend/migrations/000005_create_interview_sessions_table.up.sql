@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS interview_sessions (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    company_id INT NULL,
+    job_posting_id INT NULL,
+    interview_phase VARCHAR(100),
+    interviewer_role VARCHAR(100),
+    question_count INT NOT NULL,
+    include_self_introduction BOOLEAN NOT NULL,
+    include_ice_break BOOLEAN NOT NULL,
+    status ENUM('CREATED','GREETING','SELF_INTRODUCTION','ICE_BREAK','MAIN','PAUSED','COMPLETED','TERMINATED','CLOSING') NOT NULL DEFAULT 'CREATED',
+    started_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    ended_at TIMESTAMP NULL,
+    FOREIGN KEY (company_id) REFERENCES companies(id) ON DELETE SET NULL,
+    FOREIGN KEY (job_posting_id) REFERENCES job_postings(id) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS interview_answers;
\ No newline at end of file
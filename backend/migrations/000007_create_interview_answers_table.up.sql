@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS interview_answers (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    question_id INT NOT NULL,
+    content TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY uk_interview_answers_question (question_id),
+    FOREIGN KEY (question_id) REFERENCES interview_questions(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1 @@
+ALTER TABLE interview_questions DROP COLUMN prompt_version;
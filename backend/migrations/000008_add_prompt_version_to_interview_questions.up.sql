@@ -0,0 +1 @@
+ALTER TABLE interview_questions ADD COLUMN prompt_version VARCHAR(50) NULL AFTER sequence;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS interview_evaluations;
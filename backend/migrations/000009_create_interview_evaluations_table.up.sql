@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS interview_evaluations (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    session_id INT NOT NULL,
+    total_rank ENUM('A','B','C','D','E') NOT NULL,
+    total_score INT NOT NULL,
+    overall_comment TEXT NOT NULL,
+    logical_score INT NOT NULL,
+    communication_score INT NOT NULL,
+    technical_score INT NOT NULL,
+    problem_solving_score INT NOT NULL,
+    motivation_score INT NOT NULL,
+    culture_fit_score INT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY uk_interview_evaluations_session (session_id),
+    FOREIGN KEY (session_id) REFERENCES interview_sessions(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
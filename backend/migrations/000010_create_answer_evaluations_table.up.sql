@@ -0,0 +1,19 @@
+-- MySQLには配列型がないため、strengths / improvements はJSON配列として保存する
+CREATE TABLE IF NOT EXISTS answer_evaluations (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    session_id INT NOT NULL,
+    question_id INT NOT NULL,
+    logical_score INT NOT NULL,
+    communication_score INT NOT NULL,
+    technical_score INT NOT NULL,
+    problem_solving_score INT NOT NULL,
+    motivation_score INT NOT NULL,
+    culture_fit_score INT NOT NULL,
+    question_comment TEXT NOT NULL,
+    strengths JSON NOT NULL,
+    improvements JSON NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY uk_answer_evaluations_question (question_id),
+    FOREIGN KEY (session_id) REFERENCES interview_sessions(id) ON DELETE CASCADE,
+    FOREIGN KEY (question_id) REFERENCES interview_questions(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS users (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    auth_subject VARCHAR(255) NOT NULL,
+    email VARCHAR(255) NULL,
+    name VARCHAR(255) NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_users_auth_subject (auth_subject)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
\ No newline at end of file
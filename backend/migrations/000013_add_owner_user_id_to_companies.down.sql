@@ -0,0 +1,4 @@
+ALTER TABLE companies
+    DROP FOREIGN KEY fk_companies_owner_user_id,
+    DROP INDEX idx_companies_owner_user_id,
+    DROP COLUMN owner_user_id;
\ No newline at end of file
@@ -0,0 +1,5 @@
+-- 既存の企業は所有者が存在しないため NULL を許容する（所有者のない企業はどのユーザーからも参照できない）
+ALTER TABLE companies
+    ADD COLUMN owner_user_id INT NULL AFTER id,
+    ADD INDEX idx_companies_owner_user_id (owner_user_id),
+    ADD CONSTRAINT fk_companies_owner_user_id FOREIGN KEY (owner_user_id) REFERENCES users(id) ON DELETE CASCADE;
\ No newline at end of file
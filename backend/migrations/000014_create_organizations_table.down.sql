@@ -0,0 +1 @@
+DROP TABLE IF EXISTS organizations;
\ No newline at end of file
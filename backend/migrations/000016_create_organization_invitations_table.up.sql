@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS organization_invitations (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    organization_id INT NOT NULL,
+    token_hash CHAR(64) NOT NULL,
+    email VARCHAR(255) NULL,
+    role ENUM('admin','coach','candidate') NOT NULL,
+    invited_by_user_id INT NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    accepted_at TIMESTAMP NULL,
+    accepted_by_user_id INT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_organization_invitations_token_hash (token_hash),
+    FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE,
+    FOREIGN KEY (invited_by_user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (accepted_by_user_id) REFERENCES users(id) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
\ No newline at end of file
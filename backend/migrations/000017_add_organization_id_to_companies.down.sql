@@ -0,0 +1,4 @@
+ALTER TABLE companies
+    DROP FOREIGN KEY fk_companies_organization_id,
+    DROP INDEX idx_companies_organization_id,
+    DROP COLUMN organization_id;
\ No newline at end of file
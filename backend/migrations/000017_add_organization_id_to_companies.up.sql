@@ -0,0 +1,5 @@
+-- 組織に属する企業は組織の全メンバーが参照できる（owner_user_id は作成者を表す）
+ALTER TABLE companies
+    ADD COLUMN organization_id INT NULL AFTER owner_user_id,
+    ADD INDEX idx_companies_organization_id (organization_id),
+    ADD CONSTRAINT fk_companies_organization_id FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE;
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS job_posting_moves;
\ No newline at end of file
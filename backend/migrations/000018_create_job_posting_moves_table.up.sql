@@ -0,0 +1,13 @@
+-- 求人情報の企業間の移動履歴（移動元・移動先の企業が削除されても履歴は残す）
+CREATE TABLE IF NOT EXISTS job_posting_moves (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    job_posting_id INT NOT NULL,
+    from_company_id INT NOT NULL,
+    to_company_id INT NOT NULL,
+    moved_by_user_id INT NULL,
+    reason VARCHAR(500) NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_job_posting_moves_job_posting_id (job_posting_id),
+    FOREIGN KEY (job_posting_id) REFERENCES job_postings(id) ON DELETE CASCADE,
+    FOREIGN KEY (moved_by_user_id) REFERENCES users(id) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE companies
+    DROP INDEX uq_companies_workspace_name,
+    DROP COLUMN workspace_key;
\ No newline at end of file
@@ -0,0 +1,7 @@
+-- 企業名はワークスペース（組織、または組織に属さない企業の所有者）ごとに一意にする。
+-- 既存の重複がある場合は適用前に go run ./cmd/dedupe -apply で統合すること。
+ALTER TABLE companies
+    ADD COLUMN workspace_key VARCHAR(32) GENERATED ALWAYS AS (
+        IF(organization_id IS NULL, CONCAT('user:', owner_user_id), CONCAT('org:', organization_id))
+    ) STORED AFTER organization_id,
+    ADD UNIQUE KEY uq_companies_workspace_name (workspace_key, name);
\ No newline at end of file
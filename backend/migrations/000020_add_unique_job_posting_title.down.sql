@@ -0,0 +1,2 @@
+ALTER TABLE job_postings
+    DROP INDEX uq_job_postings_company_title;
\ No newline at end of file
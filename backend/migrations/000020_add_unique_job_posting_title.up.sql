@@ -0,0 +1,3 @@
+-- 求人タイトルは企業ごとに一意にする（既存の重複は go run ./cmd/dedupe -apply で統合すること）
+ALTER TABLE job_postings
+    ADD UNIQUE KEY uq_job_postings_company_title (company_id, title);
\ No newline at end of file
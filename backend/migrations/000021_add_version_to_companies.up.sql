@@ -0,0 +1,3 @@
+-- 楽観的排他制御のバージョン（更新のたびに 1 増やし、ETag として返す）
+ALTER TABLE companies
+    ADD COLUMN version INT UNSIGNED NOT NULL DEFAULT 1 AFTER business_description;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE job_postings
+    DROP COLUMN version;
\ No newline at end of file
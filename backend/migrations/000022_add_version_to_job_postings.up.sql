@@ -0,0 +1,3 @@
+-- 楽観的排他制御のバージョン（更新のたびに 1 増やし、ETag として返す）
+ALTER TABLE job_postings
+    ADD COLUMN version INT UNSIGNED NOT NULL DEFAULT 1 AFTER description;
\ No newline at end of file
@@ -0,0 +1,7 @@
+-- 削除済みの企業を完全に削除してから戻すこと（一意制約が重複する場合がある）
+ALTER TABLE companies
+    DROP INDEX uq_companies_workspace_name,
+    ADD UNIQUE KEY uq_companies_workspace_name (workspace_key, name),
+    DROP INDEX idx_companies_deleted_at,
+    DROP COLUMN alive,
+    DROP COLUMN deleted_at;
\ No newline at end of file
@@ -0,0 +1,7 @@
+-- 論理削除（ゴミ箱）。企業名の一意制約は削除されていない企業のみを対象にする（alive は削除済みの場合 NULL）
+ALTER TABLE companies
+    ADD COLUMN deleted_at DATETIME(3) NULL AFTER updated_at,
+    ADD COLUMN alive TINYINT GENERATED ALWAYS AS (IF(deleted_at IS NULL, 1, NULL)) STORED AFTER deleted_at,
+    ADD INDEX idx_companies_deleted_at (deleted_at),
+    DROP INDEX uq_companies_workspace_name,
+    ADD UNIQUE KEY uq_companies_workspace_name (workspace_key, name, alive);
\ No newline at end of file
@@ -0,0 +1,7 @@
+-- 削除済みの求人を完全に削除してから戻すこと（一意制約が重複する場合がある）
+ALTER TABLE job_postings
+    DROP INDEX uq_job_postings_company_title,
+    ADD UNIQUE KEY uq_job_postings_company_title (company_id, title),
+    DROP INDEX idx_job_postings_deleted_at,
+    DROP COLUMN alive,
+    DROP COLUMN deleted_at;
\ No newline at end of file
@@ -0,0 +1,7 @@
+-- 論理削除（ゴミ箱）。求人タイトルの一意制約は削除されていない求人のみを対象にする（alive は削除済みの場合 NULL）
+ALTER TABLE job_postings
+    ADD COLUMN deleted_at DATETIME(3) NULL AFTER updated_at,
+    ADD COLUMN alive TINYINT GENERATED ALWAYS AS (IF(deleted_at IS NULL, 1, NULL)) STORED AFTER deleted_at,
+    ADD INDEX idx_job_postings_deleted_at (deleted_at),
+    DROP INDEX uq_job_postings_company_title,
+    ADD UNIQUE KEY uq_job_postings_company_title (company_id, title, alive);
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE companies
+    DROP INDEX ft_companies_name_description;
\ No newline at end of file
@@ -0,0 +1,3 @@
+-- 企業一覧の q 検索（日本語を含むため ngram パーサーを使用する）
+ALTER TABLE companies
+    ADD FULLTEXT INDEX ft_companies_name_description (name, business_description) WITH PARSER ngram;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE company_custom_fields
+    DROP INDEX ft_company_custom_fields_content;
\ No newline at end of file
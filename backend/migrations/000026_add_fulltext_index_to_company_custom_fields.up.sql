@@ -0,0 +1,3 @@
+-- 企業一覧の q 検索で追加情報の内容も対象にする
+ALTER TABLE company_custom_fields
+    ADD FULLTEXT INDEX ft_company_custom_fields_content (content) WITH PARSER ngram;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE job_postings
+    DROP INDEX ft_job_postings_title;
\ No newline at end of file
@@ -0,0 +1,3 @@
+-- 企業一覧の q 検索で求人タイトルも対象にする
+ALTER TABLE job_postings
+    ADD FULLTEXT INDEX ft_job_postings_title (title) WITH PARSER ngram;
\ No newline at end of file
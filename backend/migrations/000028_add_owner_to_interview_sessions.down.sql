@@ -0,0 +1,7 @@
+ALTER TABLE interview_sessions
+    DROP FOREIGN KEY fk_interview_sessions_organization_id,
+    DROP FOREIGN KEY fk_interview_sessions_owner_user_id,
+    DROP INDEX idx_interview_sessions_organization_id,
+    DROP INDEX idx_interview_sessions_owner_user_id,
+    DROP COLUMN organization_id,
+    DROP COLUMN owner_user_id;
\ No newline at end of file
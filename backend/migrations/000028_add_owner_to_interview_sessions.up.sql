@@ -0,0 +1,8 @@
+-- 面接セッションは作成したユーザーのみが参照できる（既存のセッションは所有者が存在しないため NULL を許容し、どのユーザーからも参照できない）
+ALTER TABLE interview_sessions
+    ADD COLUMN owner_user_id INT NULL AFTER id,
+    ADD COLUMN organization_id INT NULL AFTER owner_user_id,
+    ADD INDEX idx_interview_sessions_owner_user_id (owner_user_id),
+    ADD INDEX idx_interview_sessions_organization_id (organization_id),
+    ADD CONSTRAINT fk_interview_sessions_owner_user_id FOREIGN KEY (owner_user_id) REFERENCES users(id) ON DELETE CASCADE,
+    ADD CONSTRAINT fk_interview_sessions_organization_id FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE;
\ No newline at end of file
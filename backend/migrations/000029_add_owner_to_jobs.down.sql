@@ -0,0 +1,7 @@
+ALTER TABLE jobs
+    DROP FOREIGN KEY fk_jobs_organization_id,
+    DROP FOREIGN KEY fk_jobs_owner_user_id,
+    DROP INDEX idx_jobs_organization_id,
+    DROP INDEX idx_jobs_owner_user_id,
+    DROP COLUMN organization_id,
+    DROP COLUMN owner_user_id;
\ No newline at end of file
@@ -0,0 +1,3 @@
+-- 認証プロバイダーが確認済みのメールアドレスかどうか（宛先を指定した招待の受諾時に照合する）
+ALTER TABLE users
+    ADD COLUMN email_verified BOOLEAN NOT NULL DEFAULT FALSE AFTER email;
\ No newline at end of file
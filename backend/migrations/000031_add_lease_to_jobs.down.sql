@@ -0,0 +1,4 @@
+ALTER TABLE jobs
+    DROP INDEX idx_jobs_status_lease_expires_at,
+    DROP COLUMN lease_expires_at,
+    DROP COLUMN locked_by;
\ No newline at end of file
@@ -0,0 +1,5 @@
+-- ジョブを実行中のワーカーとリース期限（複数のサーバーで実行する場合に、停止したサーバーのジョブのみを再投入する）
+ALTER TABLE jobs
+    ADD COLUMN locked_by VARCHAR(128) NULL AFTER timeout_seconds,
+    ADD COLUMN lease_expires_at TIMESTAMP NULL AFTER locked_by,
+    ADD INDEX idx_jobs_status_lease_expires_at (status, lease_expires_at);
\ No newline at end of file
@@ -5,14 +5,19 @@ DELETE FROM job_postings;
 DELETE FROM company_custom_fields;
 DELETE FROM companies;
 
+-- 企業の所有者（cmd/devtoken の既定の sub）
+INSERT INTO users (auth_subject, name) VALUES ('dev-user', '開発ユーザー')
+ON DUPLICATE KEY UPDATE id = LAST_INSERT_ID(id);
+SET @owner_id = LAST_INSERT_ID();
+
 -- 企業データのシード
-INSERT INTO companies (name, business_description) VALUES 
-('株式会社テックイノベーション', 'AIと機械学習を活用した革新的なソリューションを提供する企業です。クラウドサービス、データ分析、自然言語処理など、最先端技術を駆使したサービスを展開しています。'),
-('グローバルコンサルティング株式会社', '世界各国の企業に対して、経営戦略、デジタルトランスフォーメーション、組織改革などのコンサルティングサービスを提供しています。'),
-('未来フィンテック株式会社', 'ブロックチェーン技術を活用した次世代の金融サービスを開発。個人向けおよび法人向けの革新的な決済ソリューションを提供しています。'),
-('エコテクノロジー株式会社', '再生可能エネルギーとスマートグリッド技術を組み合わせた環境配慮型のエネルギーマネジメントシステムを開発・提供しています。'),
-('ヘルスケアソリューションズ株式会社', 'IoTとAIを活用した遠隔医療プラットフォームの開発・運営。予防医療から治療後のケアまで、包括的な医療サービスを提供しています。'),
-('デジタルエデュケーション株式会社', 'オンライン教育プラットフォームの開発・運営。個別最適化された学習体験を提供し、生涯学習をサポートしています。');
+INSERT INTO companies (owner_user_id, name, business_description) VALUES 
+(@owner_id, '株式会社テックイノベーション', 'AIと機械学習を活用した革新的なソリューションを提供する企業です。クラウドサービス、データ分析、自然言語処理など、最先端技術を駆使したサービスを展開しています。'),
+(@owner_id, 'グローバルコンサルティング株式会社', '世界各国の企業に対して、経営戦略、デジタルトランスフォーメーション、組織改革などのコンサルティングサービスを提供しています。'),
+(@owner_id, '未来フィンテック株式会社', 'ブロックチェーン技術を活用した次世代の金融サービスを開発。個人向けおよび法人向けの革新的な決済ソリューションを提供しています。'),
+(@owner_id, 'エコテクノロジー株式会社', '再生可能エネルギーとスマートグリッド技術を組み合わせた環境配慮型のエネルギーマネジメントシステムを開発・提供しています。'),
+(@owner_id, 'ヘルスケアソリューションズ株式会社', 'IoTとAIを活用した遠隔医療プラットフォームの開発・運営。予防医療から治療後のケアまで、包括的な医療サービスを提供しています。'),
+(@owner_id, 'デジタルエデュケーション株式会社', 'オンライン教育プラットフォームの開発・運営。個別最適化された学習体験を提供し、生涯学習をサポートしています。');
 
 -- 最後に挿入したIDの取得用変数
 SET @tech_id = LAST_INSERT_ID();